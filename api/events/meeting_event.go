@@ -17,7 +17,7 @@ func StartMeetingWorker(eventChan <-chan models.MeetingEvent, chatService servic
 		go func(e models.MeetingEvent) {
 			ctx := context.Background()
 
-			msg, err := chatService.SaveSystemMessage(ctx, e.MeetingID, e.UserID, e.Type)
+			msg, err := chatService.SaveSystemMessage(ctx, e)
 			if err != nil {
 				log.Printf("Failed to save system message: %v", err)
 				return
@@ -0,0 +1,90 @@
+// api/handlers/admin_handler.go
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+)
+
+type AdminHandler struct {
+	adminService services.AdminService
+}
+
+func NewAdminHandler(as services.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: as}
+}
+
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	user, err := h.adminService.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *AdminHandler) ChangeRole(c *gin.Context) {
+	actorID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.adminService.ChangeRole(c.Request.Context(), actorID, c.Param("id"), req.Role); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role updated"})
+}
+
+func (h *AdminHandler) RevokeUserSessions(c *gin.Context) {
+	actorID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.adminService.RevokeUserSessions(c.Request.Context(), actorID, GetUserRole(c), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "all sessions revoked"})
+}
+
+func (h *AdminHandler) ListAuditLogs(c *gin.Context) {
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(apperr.BadRequest("invalid limit", err))
+			return
+		}
+		limit = n
+	}
+
+	logs, err := h.adminService.ListAuditLogs(c.Request.Context(), c.Query("subject"), limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if logs == nil {
+		logs = []models.AuditLog{}
+	}
+	c.JSON(http.StatusOK, logs)
+}
@@ -3,7 +3,10 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/seojoonrp/bbiyong-backend/api/services"
@@ -12,11 +15,13 @@ import (
 )
 
 type AuthHandler struct {
-	authService services.AuthService
+	authService         services.AuthService
+	verificationService services.VerificationService
+	twoFactorService    services.TwoFactorService
 }
 
-func NewAuthHandler(service services.AuthService) *AuthHandler {
-	return &AuthHandler{authService: service}
+func NewAuthHandler(service services.AuthService, vs services.VerificationService, tfs services.TwoFactorService) *AuthHandler {
+	return &AuthHandler{authService: service, verificationService: vs, twoFactorService: tfs}
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -42,16 +47,26 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := h.authService.Login(c.Request.Context(), req)
+	tokens, user, challengeToken, err := h.authService.Login(c.Request.Context(), req, GetClientInfo(c))
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
+	// 2단계 인증이 필요하면 /auth/2fa/verify로 이어서 진행
+	if challengeToken != "" {
+		c.JSON(http.StatusOK, gin.H{
+			"twoFactorRequired": true,
+			"challengeToken":    challengeToken,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"accessToken": token,
-		"user":        user,
-		"isNewUser":   false,
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"user":         user,
+		"isNewUser":    false,
 	})
 }
 
@@ -65,16 +80,17 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 		return
 	}
 
-	isNewUser, token, user, err := h.authService.LoginWithGoogle(c.Request.Context(), req.IDToken)
+	isNewUser, tokens, user, err := h.authService.LoginWithGoogle(c.Request.Context(), req.IDToken, GetClientInfo(c))
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"accessToken": token,
-		"user":        user,
-		"isNewUser":   isNewUser,
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"user":         user,
+		"isNewUser":    isNewUser,
 	})
 }
 
@@ -88,16 +104,17 @@ func (h *AuthHandler) KakaoLogin(c *gin.Context) {
 		return
 	}
 
-	isNew, token, user, err := h.authService.LoginWithKakao(c.Request.Context(), req.AccessToken)
+	isNew, tokens, user, err := h.authService.LoginWithKakao(c.Request.Context(), req.AccessToken, GetClientInfo(c))
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"accessToken": token,
-		"user":        user,
-		"isNewUser":   isNew,
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"user":         user,
+		"isNewUser":    isNew,
 	})
 }
 
@@ -115,20 +132,167 @@ func (h *AuthHandler) AppleLogin(c *gin.Context) {
 		return
 	}
 
-	isNew, token, user, err := h.authService.LoginWithApple(c.Request.Context(), req.IdentityToken)
+	isNew, tokens, user, err := h.authService.LoginWithApple(c.Request.Context(), req.IdentityToken, GetClientInfo(c))
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"user":         user,
+		"isNewUser":    isNew,
+	})
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
 
+	tokens, err := h.authService.Refresh(c.Request.Context(), req.RefreshToken, GetClientInfo(c))
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"accessToken": token,
-		"user":        user,
-		"isNewUser":   isNew,
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
 	})
 }
 
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	jti, expiresAt, err := GetTokenInfo(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 바디는 선택. 리프레시 토큰을 같이 보내면 그것도 폐기함
+	var req models.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), userID, GetSessionID(c), jti, expiresAt, req.RefreshToken); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.authService.LogoutAll(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out from all devices"})
+}
+
+// provider 경로 파라미터는 소문자(google, kakao, apple)로 받음
+func identityProviderParam(c *gin.Context) (string, error) {
+	provider := strings.ToUpper(c.Param("provider"))
+	switch provider {
+	case models.ProviderGoogle, models.ProviderKakao, models.ProviderApple, models.ProviderLocal:
+		return provider, nil
+	default:
+		return "", apperr.BadRequest("unsupported provider", nil)
+	}
+}
+
+func (h *AuthHandler) LinkSocialIdentity(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	provider, err := identityProviderParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if provider == models.ProviderLocal {
+		c.Error(apperr.BadRequest("use the local credentials endpoint instead", nil))
+		return
+	}
+
+	var req models.LinkSocialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.authService.LinkSocialIdentity(c.Request.Context(), userID, provider, req.Token); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "login method linked successfully"})
+}
+
+func (h *AuthHandler) LinkLocalCredentials(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.LinkLocalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.authService.LinkLocalCredentials(c.Request.Context(), userID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "login method linked successfully"})
+}
+
+func (h *AuthHandler) UnlinkIdentity(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	provider, err := identityProviderParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.authService.UnlinkIdentity(c.Request.Context(), userID, provider); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "login method unlinked successfully"})
+}
+
 func (h *AuthHandler) CheckUsername(c *gin.Context) {
 	username := c.Query("username")
 	if username == "" {
@@ -166,3 +330,193 @@ func (h *AuthHandler) SetProfile(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "profile completed successfully"})
 }
+
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.verificationService.ChangeEmail(c.Request.Context(), userID, req.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "verification code sent"})
+}
+
+func (h *AuthHandler) ResendEmailVerification(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.verificationService.SendEmailVerification(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "verification code sent"})
+}
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.verificationService.VerifyEmail(c.Request.Context(), userID, req.Code); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "email verified"})
+}
+
+// 가입 여부와 상관없이 항상 같은 응답
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req models.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.verificationService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the email is registered, a reset link has been sent"})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.verificationService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password has been reset"})
+}
+
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req models.TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	tokens, user, err := h.authService.VerifyTwoFactor(c.Request.Context(), req.ChallengeToken, req.Code, GetClientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"user":         user,
+		"isNewUser":    false,
+	})
+}
+
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setup, err := h.twoFactorService.Setup(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	codes, err := h.twoFactorService.Enable(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": codes})
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.twoFactorService.Disable(c.Request.Context(), userID, req.Code, GetClientInfo(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor authentication disabled"})
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code, GetClientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": codes})
+}
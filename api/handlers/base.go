@@ -3,8 +3,11 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
 )
 
 func GetUserID(c *gin.Context) (string, error) {
@@ -20,3 +23,32 @@ func GetUserID(c *gin.Context) (string, error) {
 
 	return uIDStr, nil
 }
+
+// 토큰에 묶인 세션 ID. 세션 도입 전에 발급된 토큰이면 빈 문자열
+func GetSessionID(c *gin.Context) string {
+	return c.GetString("session_id")
+}
+
+// 토큰에 실린 역할. 역할이 바뀌면 기존 토큰은 폐기되므로 항상 최신 값임
+func GetUserRole(c *gin.Context) string {
+	return c.GetString("role")
+}
+
+// 기기 이름은 앱이 X-Device-Name 헤더로 보내줌
+func GetClientInfo(c *gin.Context) models.ClientInfo {
+	return models.ClientInfo{
+		DeviceName: c.GetHeader("X-Device-Name"),
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+}
+
+// 현재 요청에 사용된 액세스 토큰의 jti와 만료 시각
+func GetTokenInfo(c *gin.Context) (string, time.Time, error) {
+	jti := c.GetString("token_id")
+	if jti == "" {
+		return "", time.Time{}, apperr.Unauthorized("user session expired", nil)
+	}
+
+	return jti, c.GetTime("token_expires_at"), nil
+}
@@ -0,0 +1,41 @@
+// api/handlers/health_handler.go
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/jwks"
+)
+
+type HealthHandler struct {
+	keyCaches []*jwks.Cache
+}
+
+func NewHealthHandler(keyCaches []*jwks.Cache) *HealthHandler {
+	return &HealthHandler{keyCaches: keyCaches}
+}
+
+// 외부 공개키 캐시 상태. 하나라도 비정상이면 503
+func (h *HealthHandler) Check(c *gin.Context) {
+	healthy := true
+	statuses := make([]jwks.Health, 0, len(h.keyCaches))
+	for _, kc := range h.keyCaches {
+		status := kc.Health(c.Request.Context())
+		if !status.Healthy {
+			healthy = false
+		}
+		statuses = append(statuses, status)
+	}
+
+	code := http.StatusOK
+	if !healthy {
+		code = http.StatusServiceUnavailable
+	}
+
+	c.JSON(code, gin.H{
+		"healthy": healthy,
+		"jwks":    statuses,
+	})
+}
@@ -0,0 +1,24 @@
+// api/handlers/jwks_handler.go
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+)
+
+type JWKSHandler struct {
+	tokenService services.TokenService
+}
+
+func NewJWKSHandler(ts services.TokenService) *JWKSHandler {
+	return &JWKSHandler{tokenService: ts}
+}
+
+// 다음 차례 키는 publish lead만큼 미리 노출되므로 짧게 캐시해도 충분함
+func (h *JWKSHandler) GetKeys(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.tokenService.JWKS())
+}
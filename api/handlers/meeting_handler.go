@@ -3,6 +3,8 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -13,11 +15,13 @@ import (
 )
 
 type MeetingHandler struct {
-	service services.MeetingService
+	service            services.MeetingService
+	joinRequestService services.JoinRequestService
+	waitlistService    services.WaitlistService
 }
 
-func NewMeetingHandler(s services.MeetingService) *MeetingHandler {
-	return &MeetingHandler{service: s}
+func NewMeetingHandler(s services.MeetingService, jrs services.JoinRequestService, ws services.WaitlistService) *MeetingHandler {
+	return &MeetingHandler{service: s, joinRequestService: jrs, waitlistService: ws}
 }
 
 func (h *MeetingHandler) CreateMeeting(c *gin.Context) {
@@ -42,6 +46,12 @@ func (h *MeetingHandler) CreateMeeting(c *gin.Context) {
 }
 
 func (h *MeetingHandler) GetNearby(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	lat, err := strconv.ParseFloat(c.Query("latitude"), 64)
 	lon, err := strconv.ParseFloat(c.Query("longitude"), 64)
 	radius, err := strconv.ParseFloat(c.Query("radius"), 64)
@@ -51,8 +61,9 @@ func (h *MeetingHandler) GetNearby(c *gin.Context) {
 	}
 
 	daysStr := c.QueryArray("day_of_week")
+	eligibleOnly := c.Query("eligible_only") == "true"
 
-	meetings, err := h.service.GetNearbyMeetings(c.Request.Context(), lon, lat, radius, daysStr)
+	meetings, err := h.service.GetNearbyMeetings(c.Request.Context(), userID, lon, lat, radius, daysStr, eligibleOnly)
 	if err != nil {
 		c.Error(err)
 		return
@@ -61,6 +72,139 @@ func (h *MeetingHandler) GetNearby(c *gin.Context) {
 	c.JSON(http.StatusOK, meetings)
 }
 
+func (h *MeetingHandler) GetMeeting(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	detail, err := h.service.GetMeetingDetail(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+func (h *MeetingHandler) UpdateMeeting(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.UpdateMeetingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	meeting, err := h.service.UpdateMeeting(c.Request.Context(), c.Param("id"), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, meeting)
+}
+
+func (h *MeetingHandler) CancelMeeting(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 바디는 선택. 사유 없이 취소 가능
+	var req models.CancelMeetingRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.service.CancelMeeting(c.Request.Context(), c.Param("id"), userID, req.Reason); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "successfully cancelled the meeting"})
+}
+
+func (h *MeetingHandler) TransferHost(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.TransferHostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.service.TransferHost(c.Request.Context(), c.Param("id"), userID, req.UserID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "successfully transferred the host"})
+}
+
+func (h *MeetingHandler) AddCoHost(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.AddCoHost(c.Request.Context(), c.Param("id"), userID, c.Param("userID")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "co-host added successfully"})
+}
+
+func (h *MeetingHandler) RemoveCoHost(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.RemoveCoHost(c.Request.Context(), c.Param("id"), userID, c.Param("userID")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "co-host removed successfully"})
+}
+
+func (h *MeetingHandler) KickParticipant(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 바디는 선택. 기본은 강퇴만 하고 재참여는 허용
+	var req models.KickParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	if err := h.service.KickParticipant(c.Request.Context(), c.Param("id"), userID, c.Param("userID"), req.Ban); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "participant kicked successfully"})
+}
+
 func (h *MeetingHandler) Join(c *gin.Context) {
 	userID, err := GetUserID(c)
 	if err != nil {
@@ -70,15 +214,142 @@ func (h *MeetingHandler) Join(c *gin.Context) {
 
 	meetingID := c.Param("id")
 
-	err = h.service.JoinMeeting(c.Request.Context(), meetingID, userID)
+	// 바디는 선택. 승인제 모임에 신청할 때 남기는 말
+	var req models.JoinMeetingRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	joinRequest, err := h.service.JoinMeeting(c.Request.Context(), meetingID, userID, req.Message)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
+	if joinRequest != nil {
+		c.JSON(http.StatusAccepted, gin.H{"message": "join request submitted", "joinRequest": joinRequest})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "successfully joined the meeting"})
 }
 
+func (h *MeetingHandler) ListJoinRequests(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	requests, err := h.joinRequestService.ListPending(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if requests == nil {
+		requests = []models.JoinRequestView{}
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+func (h *MeetingHandler) ApproveJoinRequest(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.joinRequestService.Approve(c.Request.Context(), c.Param("id"), c.Param("requestID"), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "join request approved"})
+}
+
+func (h *MeetingHandler) RejectJoinRequest(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.joinRequestService.Reject(c.Request.Context(), c.Param("id"), c.Param("requestID"), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "join request rejected"})
+}
+
+func (h *MeetingHandler) CancelJoinRequest(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.joinRequestService.Cancel(c.Request.Context(), c.Param("id"), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "join request cancelled"})
+}
+
+func (h *MeetingHandler) JoinWaitlist(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	position, err := h.waitlistService.Join(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 대기열에 들어가는 사이에 자리가 나서 바로 참여된 경우
+	if position == nil {
+		c.JSON(http.StatusOK, gin.H{"message": "successfully joined the meeting"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "joined the waitlist", "waitlist": position})
+}
+
+func (h *MeetingHandler) LeaveWaitlist(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.waitlistService.Leave(c.Request.Context(), c.Param("id"), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "left the waitlist"})
+}
+
+func (h *MeetingHandler) GetWaitlistPosition(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	position, err := h.waitlistService.GetPosition(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, position)
+}
+
 func (h *MeetingHandler) Leave(c *gin.Context) {
 	userID, err := GetUserID(c)
 	if err != nil {
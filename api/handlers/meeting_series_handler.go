@@ -0,0 +1,88 @@
+// api/handlers/meeting_series_handler.go
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+)
+
+type MeetingSeriesHandler struct {
+	seriesService services.MeetingSeriesService
+}
+
+func NewMeetingSeriesHandler(ss services.MeetingSeriesService) *MeetingSeriesHandler {
+	return &MeetingSeriesHandler{seriesService: ss}
+}
+
+func (h *MeetingSeriesHandler) CreateSeries(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.CreateMeetingSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	series, err := h.seriesService.CreateSeries(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+func (h *MeetingSeriesHandler) GetSeries(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	series, err := h.seriesService.GetSeries(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (h *MeetingSeriesHandler) OptIn(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.seriesService.OptIn(c.Request.Context(), c.Param("id"), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "joined the series as a regular member"})
+}
+
+func (h *MeetingSeriesHandler) OptOut(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.seriesService.OptOut(c.Request.Context(), c.Param("id"), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "left the series"})
+}
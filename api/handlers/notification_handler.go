@@ -0,0 +1,65 @@
+// api/handlers/notification_handler.go
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+}
+
+func NewNotificationHandler(ns services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: ns}
+}
+
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(apperr.BadRequest("invalid limit", err))
+			return
+		}
+		limit = n
+	}
+
+	notifications, err := h.notificationService.ListNotifications(c.Request.Context(), userID, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if notifications == nil {
+		notifications = []models.Notification{}
+	}
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.notificationService.MarkAllRead(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notifications marked as read"})
+}
@@ -0,0 +1,156 @@
+// api/handlers/user_handler.go
+
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+)
+
+type UserHandler struct {
+	userService    services.UserService
+	sessionService services.SessionService
+	accountService services.AccountService
+}
+
+func NewUserHandler(us services.UserService, ss services.SessionService, as services.AccountService) *UserHandler {
+	return &UserHandler{userService: us, sessionService: ss, accountService: as}
+}
+
+func (h *UserHandler) GetMe(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	profile, err := h.userService.GetMe(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *UserHandler) GetUser(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	profile, err := h.userService.GetProfile(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req models.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 바디는 선택. 기본값은 내가 방장인 모임을 가장 오래된 참여자에게 넘김
+	var req models.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(apperr.BadRequest("invalid request body", err))
+		return
+	}
+
+	scheduledAt, err := h.accountService.RequestDeletion(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":             "account deletion scheduled",
+		"deletionScheduledAt": scheduledAt,
+	})
+}
+
+func (h *UserHandler) RestoreMe(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.accountService.CancelDeletion(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "account deletion cancelled"})
+}
+
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userID, GetSessionID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if sessions == nil {
+		sessions = []models.Session{}
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sessionID := c.Param("id")
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "session revoked successfully"})
+}
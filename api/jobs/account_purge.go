@@ -0,0 +1,54 @@
+// api/jobs/account_purge.go
+
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	accountPurgeLease   = "account_purge"
+	accountPurgeTimeout = 5 * time.Minute
+)
+
+// 탈퇴 유예 기간이 끝난 계정을 주기적으로 정리
+// 같은 계정을 여러 대가 동시에 정리하면 취소 알림과 이벤트가 겹쳐 나가므로 임대를 잡은 한 대만 처리함
+func StartAccountPurgeJob(accountService services.AccountService, leases repositories.JobLeaseRepository, interval time.Duration) {
+	owner := primitive.NewObjectID().Hex()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), accountPurgeTimeout)
+
+		// 주기가 짧아도 처리 시간 제한보다는 길게 잡음
+		acquired, err := leases.Acquire(ctx, accountPurgeLease, owner, time.Now(), max(2*interval, accountPurgeTimeout))
+		if err != nil {
+			log.Println("Failed to acquire account purge lease:", err)
+			cancel()
+			continue
+		}
+		if !acquired {
+			cancel()
+			continue
+		}
+
+		purged, err := accountService.PurgeDueAccounts(ctx)
+		cancel()
+
+		if err != nil {
+			log.Println("Failed to purge deleted accounts:", err)
+			continue
+		}
+		if purged > 0 {
+			log.Printf("Purged %d deleted accounts", purged)
+		}
+	}
+}
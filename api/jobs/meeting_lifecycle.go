@@ -0,0 +1,51 @@
+// api/jobs/meeting_lifecycle.go
+
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const meetingLifecycleLease = "meeting_lifecycle"
+
+// 모임 시간이 되면 진행 중으로, 진행 시간이 지나면 종료로 바꿈
+// 서버가 여러 대여도 임대를 잡은 한 대만 처리함
+func StartMeetingLifecycleJob(meetingService services.MeetingService, leases repositories.JobLeaseRepository, interval time.Duration) {
+	owner := primitive.NewObjectID().Hex()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+
+		// 처리 도중 임대가 풀리지 않도록 주기의 두 배만큼 잡아 둠
+		acquired, err := leases.Acquire(ctx, meetingLifecycleLease, owner, time.Now(), 2*interval)
+		if err != nil {
+			log.Println("Failed to acquire meeting lifecycle lease:", err)
+			cancel()
+			continue
+		}
+		if !acquired {
+			cancel()
+			continue
+		}
+
+		started, finished, err := meetingService.AdvanceLifecycle(ctx, time.Now())
+		cancel()
+
+		if err != nil {
+			log.Println("Failed to advance meeting lifecycle:", err)
+			continue
+		}
+		if started > 0 || finished > 0 {
+			log.Printf("Meeting lifecycle: %d started, %d finished", started, finished)
+		}
+	}
+}
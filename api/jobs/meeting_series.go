@@ -0,0 +1,50 @@
+// api/jobs/meeting_series.go
+
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const meetingSeriesLease = "meeting_series"
+
+// 반복 모임의 다가오는 회차를 미리 만들어 둠. 회차마다 유니크 인덱스가 있어서 겹쳐 돌아도 중복은 안 생기지만
+// 불필요한 조회를 줄이려고 임대를 잡은 한 대만 처리함
+func StartMeetingSeriesJob(seriesService services.MeetingSeriesService, leases repositories.JobLeaseRepository, interval time.Duration) {
+	owner := primitive.NewObjectID().Hex()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+
+		acquired, err := leases.Acquire(ctx, meetingSeriesLease, owner, time.Now(), 2*interval)
+		if err != nil {
+			log.Println("Failed to acquire meeting series lease:", err)
+			cancel()
+			continue
+		}
+		if !acquired {
+			cancel()
+			continue
+		}
+
+		created, err := seriesService.MaterializeDue(ctx, time.Now())
+		cancel()
+
+		if err != nil {
+			log.Println("Failed to materialize meeting series:", err)
+			continue
+		}
+		if created > 0 {
+			log.Printf("Meeting series: %d occurrences created", created)
+		}
+	}
+}
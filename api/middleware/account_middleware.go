@@ -0,0 +1,36 @@
+// api/middleware/account_middleware.go
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+)
+
+// AuthMiddleware 뒤에 붙여서 사용. 탈퇴 유예 중인 계정은 복구하기 전까지 막음
+// 토큰의 dp 클레임이 true여도 발급 이후에 복구했을 수 있으니 DB를 한 번 더 확인함
+func RequireActiveAccount(users services.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("deletion_pending") {
+			c.Next()
+			return
+		}
+
+		user, err := users.GetUserByID(c.Request.Context(), c.GetString("user_id"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if user.DeletionScheduledAt != nil {
+			c.Error(apperr.Forbidden("account deletion is pending, restore the account first", nil).WithCode(apperr.CodeDeletionPending))
+			c.Abort()
+			return
+		}
+
+		c.Set("deletion_pending", false)
+		c.Next()
+	}
+}
@@ -3,16 +3,15 @@
 package middleware
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
 	"github.com/seojoonrp/bbiyong-backend/apperr"
-	"github.com/seojoonrp/bbiyong-backend/config"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// 서명 검증은 TokenService의 키 세트로, 폐기 여부는 메모리 캐시로 확인
+func AuthMiddleware(tokens services.TokenService, revocations services.RevocationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -23,38 +22,26 @@ func AuthMiddleware() gin.HandlerFunc {
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(config.AppConfig.JWTSecret), nil
-		})
+		claims, err := tokens.ParseAccessToken(tokenString)
 		if err != nil {
-			c.Error(apperr.Unauthorized("invalid or expired token", err))
-			c.Abort()
-			return
-		}
-		if !token.Valid {
-			c.Error(apperr.Unauthorized("invalid token", nil))
-			c.Abort()
-			return
-		}
-
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.Error(apperr.Unauthorized("invalid token claims", nil))
+			c.Error(err)
 			c.Abort()
 			return
 		}
 
-		userID, ok := claims["user_id"].(string)
-		if !ok {
-			c.Error(apperr.Unauthorized("invalid user ID in token", nil))
+		if revocations.IsRevoked(claims.JTI, claims.UserID, claims.SessionID, claims.TokenVersion) {
+			c.Error(apperr.Unauthorized("token has been revoked", nil))
 			c.Abort()
 			return
 		}
 
-		c.Set("user_id", userID)
+		c.Set("user_id", claims.UserID)
+		c.Set("token_id", claims.JTI)
+		c.Set("session_id", claims.SessionID)
+		c.Set("role", claims.Role)
+		c.Set("profile_set", claims.ProfileSet)
+		c.Set("deletion_pending", claims.DeletionPending)
+		c.Set("token_expires_at", claims.ExpiresAt)
 		c.Next()
 	}
 }
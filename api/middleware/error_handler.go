@@ -21,7 +21,14 @@ func ErrorHandler() gin.HandlerFunc {
 				if appErr.Raw != nil {
 					fmt.Printf("[ERROR] %v\n", appErr.Raw)
 				}
-				c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+				for key, value := range appErr.Headers {
+					c.Header(key, value)
+				}
+				body := gin.H{"error": appErr.Message}
+				if appErr.Code != "" {
+					body["code"] = appErr.Code
+				}
+				c.JSON(appErr.StatusCode, body)
 			} else {
 				fmt.Printf("[UNKNOWN ERROR] %v\n", err)
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
@@ -0,0 +1,36 @@
+// api/middleware/profile_middleware.go
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+)
+
+// AuthMiddleware 뒤에 붙여서 사용. 프로필을 다 채운 유저만 통과
+// 토큰의 ps 클레임이 false여도 발급 이후에 프로필을 채웠을 수 있으니 DB를 한 번 더 확인함
+func RequireCompleteProfile(users services.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("profile_set") {
+			c.Next()
+			return
+		}
+
+		user, err := users.GetUserByID(c.Request.Context(), c.GetString("user_id"))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if !user.IsProfileSet {
+			c.Error(apperr.Forbidden("profile is not set yet", nil).WithCode(apperr.CodeProfileIncomplete))
+			c.Abort()
+			return
+		}
+
+		c.Set("profile_set", true)
+		c.Next()
+	}
+}
@@ -0,0 +1,21 @@
+// api/middleware/role_middleware.go
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+)
+
+// AuthMiddleware 뒤에 붙여서 사용. minimum 이상의 역할만 통과
+func RequireRole(minimum string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !models.RoleAtLeast(c.GetString("role"), minimum) {
+			c.Error(apperr.Forbidden("insufficient role", nil))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,51 @@
+// api/repositories/audit_log_repository.go
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *models.AuditLog) error
+	List(ctx context.Context, subject string, limit int64) ([]models.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuditLogRepository(db *mongo.Database) AuditLogRepository {
+	return &auditLogRepository{collection: db.Collection("audit_logs")}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *models.AuditLog) error {
+	_, err := r.collection.InsertOne(ctx, log)
+	return err
+}
+
+// subject가 비어 있으면 전체에서 최신순
+func (r *auditLogRepository) List(ctx context.Context, subject string, limit int64) ([]models.AuditLog, error) {
+	filter := bson.M{}
+	if subject != "" {
+		filter["subject"] = subject
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []models.AuditLog
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
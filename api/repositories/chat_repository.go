@@ -9,12 +9,12 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type ChatRepository interface {
 	SaveMessage(ctx context.Context, msg *models.ChatMessage) error
 	GetChatHistory(ctx context.Context, meetingID primitive.ObjectID, limit int64) ([]models.ChatMessage, error)
+	AnonymizeSender(ctx context.Context, senderID primitive.ObjectID, name string) error
 }
 
 type chatRepository struct {
@@ -32,13 +32,38 @@ func (r *chatRepository) SaveMessage(ctx context.Context, msg *models.ChatMessag
 	return err
 }
 
+// 일반 메시지의 발신자 이름/사진은 저장된 값 대신 현재 프로필로 채움.
+// 탈퇴로 유저가 없으면 익명화된 저장 값을 그대로 씀
 func (r *chatRepository) GetChatHistory(ctx context.Context, meetingID primitive.ObjectID, limit int64) ([]models.ChatMessage, error) {
 	var messages []models.ChatMessage
-	filter := bson.M{"meeting_id": meetingID}
 
-	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit)
+	isLiveTalk := bson.M{"$and": bson.A{
+		bson.M{"$eq": bson.A{"$type", models.ChatTypeTalk}},
+		bson.M{"$gt": bson.A{bson.M{"$size": "$sender"}, 0}},
+	}}
 
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"meeting_id": meetingID}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "users",
+			"localField":   "sender_id",
+			"foreignField": "_id",
+			"as":           "sender",
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"sender_name": bson.M{"$cond": bson.A{
+				isLiveTalk, bson.M{"$arrayElemAt": bson.A{"$sender.nickname", 0}}, "$sender_name",
+			}},
+			"sender_profile_uri": bson.M{"$cond": bson.A{
+				isLiveTalk, bson.M{"$arrayElemAt": bson.A{"$sender.profile_uri", 0}}, "$sender_profile_uri",
+			}},
+		}}},
+		{{Key: "$unset", Value: "sender"}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -50,3 +75,19 @@ func (r *chatRepository) GetChatHistory(ctx context.Context, meetingID primitive
 
 	return messages, nil
 }
+
+// 시스템 메시지는 이름이 "System"이라 건드리지 않음
+func (r *chatRepository) AnonymizeSender(ctx context.Context, senderID primitive.ObjectID, name string) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"sender_id": senderID,
+			"type":      models.ChatTypeTalk,
+		},
+		bson.M{"$set": bson.M{
+			"sender_name":        name,
+			"sender_profile_uri": "",
+		}},
+	)
+	return err
+}
@@ -18,6 +18,8 @@ type FriendRepository interface {
 	FindByUserIDs(ctx context.Context, uID1, uID2 primitive.ObjectID) (*models.Friendship, error)
 	UpdateStatus(ctx context.Context, fID primitive.ObjectID, status string) error
 	GetFriendList(ctx context.Context, uID primitive.ObjectID, status string) ([]models.FriendInfo, error)
+	DeleteAllByUser(ctx context.Context, uID primitive.ObjectID) error
+	FindFriendIDs(ctx context.Context, uID primitive.ObjectID) ([]primitive.ObjectID, error)
 }
 
 type friendRepository struct {
@@ -110,7 +112,7 @@ func (r *friendRepository) GetFriendList(ctx context.Context, uID primitive.Obje
 			"friendshipID": "$_id",
 			"friendID":     "$target_id",
 			"nickname":     "$friend_detail.nickname",
-			"profileURI":   "$friend_detail.profileURI",
+			"profileURI":   "$friend_detail.profile_uri",
 			"status":       "$status",
 			"updatedAt":    "$updated_at",
 		}}},
@@ -128,3 +130,46 @@ func (r *friendRepository) GetFriendList(ctx context.Context, uID primitive.Obje
 	}
 	return results, nil
 }
+
+func (r *friendRepository) DeleteAllByUser(ctx context.Context, uID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{
+		"$or": []bson.M{{"requester_id": uID}, {"addressee_id": uID}},
+	})
+	return err
+}
+
+// 수락된 친구들의 ID만
+func (r *friendRepository) FindFriendIDs(ctx context.Context, uID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"status": models.FriendStatusAccepted,
+			"$or":    []bson.M{{"requester_id": uID}, {"addressee_id": uID}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"friend_id": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$requester_id", uID}},
+				"$addressee_id",
+				"$requester_id",
+			}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		FriendID primitive.ObjectID `bson:"friend_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.FriendID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,55 @@
+// api/repositories/job_lease_repository.go
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type JobLeaseRepository interface {
+	// 비어 있거나 만료됐거나 이미 내 것이면 ttl만큼 잡음
+	Acquire(ctx context.Context, name, owner string, now time.Time, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name, owner string) error
+}
+
+type jobLeaseRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobLeaseRepository(db *mongo.Database) JobLeaseRepository {
+	return &jobLeaseRepository{collection: db.Collection("job_leases")}
+}
+
+func (r *jobLeaseRepository) Acquire(ctx context.Context, name, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	filter := bson.M{
+		"_id": name,
+		"$or": []bson.M{
+			{"expires_at": bson.M{"$lte": now}},
+			{"owner": owner},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(ttl)}}
+
+	// 다른 인스턴스가 잡고 있으면 필터에 안 걸려서 upsert가 같은 _id로 들어가다 중복 키 에러가 남
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *jobLeaseRepository) Release(ctx context.Context, name, owner string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": name, "owner": owner},
+		bson.M{"$set": bson.M{"expires_at": time.Time{}}},
+	)
+	return err
+}
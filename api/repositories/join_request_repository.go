@@ -0,0 +1,153 @@
+// api/repositories/join_request_repository.go
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type JoinRequestRepository interface {
+	Create(ctx context.Context, req *models.JoinRequest) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.JoinRequest, error)
+	FindPending(ctx context.Context, meetingID, userID primitive.ObjectID) (*models.JoinRequest, error)
+	ListPendingByMeeting(ctx context.Context, meetingID primitive.ObjectID) ([]models.JoinRequestView, error)
+	// 현재 상태가 from일 때만 바꿈
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to string, decidedBy *primitive.ObjectID, now time.Time) (bool, error)
+	DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error
+	// 대기 중인 신청을 전부 취소 처리하고 신청자를 돌려줌
+	CancelAllPending(ctx context.Context, meetingID primitive.ObjectID, now time.Time) ([]primitive.ObjectID, error)
+}
+
+type joinRequestRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJoinRequestRepository(db *mongo.Database) JoinRequestRepository {
+	return &joinRequestRepository{collection: db.Collection("join_requests")}
+}
+
+func (r *joinRequestRepository) Create(ctx context.Context, req *models.JoinRequest) error {
+	result, err := r.collection.InsertOne(ctx, req)
+	if err != nil {
+		return err
+	}
+	req.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *joinRequestRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.JoinRequest, error) {
+	var req models.JoinRequest
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&req)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *joinRequestRepository) FindPending(ctx context.Context, meetingID, userID primitive.ObjectID) (*models.JoinRequest, error) {
+	var req models.JoinRequest
+	err := r.collection.FindOne(ctx, bson.M{
+		"meeting_id": meetingID,
+		"user_id":    userID,
+		"status":     models.JoinRequestPending,
+	}).Decode(&req)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// 먼저 신청한 순서대로
+func (r *joinRequestRepository) ListPendingByMeeting(ctx context.Context, meetingID primitive.ObjectID) ([]models.JoinRequestView, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"meeting_id": meetingID, "status": models.JoinRequestPending}}},
+		{{Key: "$sort", Value: bson.M{"created_at": 1}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "users",
+			"let":  bson.M{"uid": "$user_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$uid"}}}},
+				bson.M{"$project": bson.M{"nickname": 1, "profile_uri": 1, "level": 1}},
+			},
+			"as": "applicant",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$applicant", "preserveNullAndEmptyArrays": true}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var requests []models.JoinRequestView
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *joinRequestRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to string, decidedBy *primitive.ObjectID, now time.Time) (bool, error) {
+	set := bson.M{"status": to, "decided_at": now}
+	if decidedBy != nil {
+		set["decided_by"] = *decidedBy
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": from},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *joinRequestRepository) DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+func (r *joinRequestRepository) CancelAllPending(ctx context.Context, meetingID primitive.ObjectID, now time.Time) ([]primitive.ObjectID, error) {
+	filter := bson.M{"meeting_id": meetingID, "status": models.JoinRequestPending}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"user_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var requests []models.JoinRequest
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(requests))
+	userIDs := make([]primitive.ObjectID, 0, len(requests))
+	for _, req := range requests {
+		ids = append(ids, req.ID)
+		userIDs = append(userIDs, req.UserID)
+	}
+
+	// 그 사이 승인되거나 거절된 신청은 건드리지 않음
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "status": models.JoinRequestPending},
+		bson.M{"$set": bson.M{"status": models.JoinRequestCancelled, "decided_at": now}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,159 @@
+// api/repositories/login_attempt_repository.go
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 인스턴스가 하나면 메모리, 여러 대면 Mongo 구현을 써야 카운터가 공유됨
+type LoginAttemptRepository interface {
+	Find(ctx context.Context, key string) (*models.LoginAttempt, error)
+	// 마지막 실패와 잠금 해제 시각 중 늦은 쪽에서 window가 지나면 1부터 다시 셈. 증가된 결과를 돌려줌
+	RecordFailure(ctx context.Context, key string, now time.Time, window, retention time.Duration) (*models.LoginAttempt, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+type loginAttemptRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLoginAttemptRepository(db *mongo.Database) LoginAttemptRepository {
+	return &loginAttemptRepository{collection: db.Collection("login_attempts")}
+}
+
+func (r *loginAttemptRepository) Find(ctx context.Context, key string) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := r.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&attempt)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// 여러 인스턴스에서 동시에 실패해도 카운트가 빠지지 않도록 파이프라인 업데이트 한 번으로 처리
+// 잠금 중에는 실패가 기록되지 않으므로 잠금이 window보다 길어도 풀린 직후 실패가 단계를 이어가게 함
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, window, retention time.Duration) (*models.LoginAttempt, error) {
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"failures": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{bson.M{"$max": bson.A{"$last_failure_at", "$locked_until"}}, now.Add(-window)}},
+				bson.M{"$add": bson.A{"$failures", 1}},
+				1,
+			}},
+			"last_failure_at": now,
+			"expires_at":      bson.M{"$max": bson.A{"$expires_at", now.Add(retention)}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var attempt models.LoginAttempt
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&attempt); err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": key},
+		bson.M{"$max": bson.M{"locked_until": until, "expires_at": until}},
+	)
+	return err
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*models.LoginAttempt
+}
+
+func NewMemoryLoginAttemptRepository() LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{attempts: make(map[string]*models.LoginAttempt)}
+}
+
+func (r *memoryLoginAttemptRepository) Find(ctx context.Context, key string) (*models.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok || !attempt.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+func (r *memoryLoginAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, window, retention time.Duration) (*models.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 만료된 항목은 기록할 때 함께 정리
+	for k, a := range r.attempts {
+		if !a.ExpiresAt.After(now) {
+			delete(r.attempts, k)
+		}
+	}
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = &models.LoginAttempt{Key: key}
+		r.attempts[key] = attempt
+	}
+	latest := attempt.LastFailureAt
+	if attempt.LockedUntil.After(latest) {
+		latest = attempt.LockedUntil
+	}
+	if latest.Before(now.Add(-window)) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	if expiresAt := now.Add(retention); expiresAt.After(attempt.ExpiresAt) {
+		attempt.ExpiresAt = expiresAt
+	}
+
+	copied := *attempt
+	return &copied, nil
+}
+
+func (r *memoryLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return nil
+	}
+	if until.After(attempt.LockedUntil) {
+		attempt.LockedUntil = until
+	}
+	if until.After(attempt.ExpiresAt) {
+		attempt.ExpiresAt = until
+	}
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
@@ -0,0 +1,53 @@
+// api/repositories/login_attempt_repository_test.go
+
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// 잠금이 window보다 길어도 풀린 뒤의 실패는 이전 단계에 이어서 셈
+func TestMemoryRecordFailureWindow(t *testing.T) {
+	const window = 15 * time.Minute
+	const retention = 2 * time.Hour
+	ctx := context.Background()
+	start := time.Now()
+
+	tests := []struct {
+		name         string
+		lockedFor    time.Duration // 0이면 잠그지 않음
+		nextFailure  time.Duration // 마지막 실패로부터
+		wantFailures int
+	}{
+		{"inside window", 0, 10 * time.Minute, 6},
+		{"window passed without lock", 0, 20 * time.Minute, 1},
+		{"right after a long lock", 32 * time.Minute, 33 * time.Minute, 6},
+		{"window passed after lock ended", 32 * time.Minute, 48 * time.Minute, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMemoryLoginAttemptRepository()
+			for i := 0; i < 5; i++ {
+				if _, err := r.RecordFailure(ctx, "user:a", start, window, retention); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.lockedFor > 0 {
+				if err := r.Lock(ctx, "user:a", start.Add(tt.lockedFor)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			attempt, err := r.RecordFailure(ctx, "user:a", start.Add(tt.nextFailure), window, retention)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if attempt.Failures != tt.wantFailures {
+				t.Errorf("failures = %d, want %d", attempt.Failures, tt.wantFailures)
+			}
+		})
+	}
+}
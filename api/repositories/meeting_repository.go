@@ -7,21 +7,42 @@ import (
 	"errors"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/seojoonrp/bbiyong-backend/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MeetingRepository interface {
 	Create(ctx context.Context, meeting *models.Meeting) error
 	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Meeting, error)
-	FindNearby(ctx context.Context, lon, lat float64, radiusMeter float64, days []int) ([]models.Meeting, error)
-	AddParticipant(ctx context.Context, meetingID, userID primitive.ObjectID, maxParticipants int) (bool, error)
-	RemoveParticipant(ctx context.Context, meetingID, userID primitive.ObjectID, maxParticipants int) (bool, error)
+	FindDetailByID(ctx context.Context, id primitive.ObjectID) (*models.MeetingDetail, error)
+	// eligibleFor가 있으면 그 유저가 나이, 성별, 강퇴 때문에 참여할 수 없는 모임은 뺌
+	FindNearby(ctx context.Context, lon, lat float64, radiusMeter float64, days []int, eligibleFor *models.User) ([]models.Meeting, error)
+	// gender는 성별 정원 확인용으로 같이 기록됨
+	AddParticipant(ctx context.Context, meetingID, userID primitive.ObjectID, gender string) (bool, error)
+	RemoveParticipant(ctx context.Context, meetingID, userID primitive.ObjectID) (bool, error)
 	IncrementSaveCount(ctx context.Context, meetingID primitive.ObjectID) error
 	DecrementSaveCount(ctx context.Context, meetingID primitive.ObjectID) error
+	FindByParticipant(ctx context.Context, userID primitive.ObjectID, statuses []string) ([]models.Meeting, error)
+	// afterOccurrence보다 뒤 회차를 회차 순으로. statuses가 비어 있으면 상태 상관없이
+	FindBySeries(ctx context.Context, seriesID primitive.ObjectID, afterOccurrence int, statuses []string) ([]models.Meeting, error)
+	ExistsSeriesParticipant(ctx context.Context, seriesID, userID primitive.ObjectID) (bool, error)
+	UpdateStatus(ctx context.Context, meetingID primitive.ObjectID, fromStatuses []string, status string) (bool, error)
+	TransferHost(ctx context.Context, meetingID, fromID, toID primitive.ObjectID) (bool, error)
+	Update(ctx context.Context, meetingID, editorID primitive.ObjectID, updates bson.M, maxParticipants int) (bool, error)
+	AddCoHost(ctx context.Context, meetingID, hostID, userID primitive.ObjectID) (bool, error)
+	RemoveCoHost(ctx context.Context, meetingID, userID primitive.ObjectID) (bool, error)
+	Ban(ctx context.Context, meetingID, userID primitive.ObjectID) error
+	PromoteFromWaitlist(ctx context.Context, entry *models.WaitlistEntry, gender string) (bool, error)
+	IncrementWaitlistCount(ctx context.Context, meetingID primitive.ObjectID, gender string, delta int) error
+	ResetWaitlistCount(ctx context.Context, meetingID primitive.ObjectID) error
+	FindByStatusBefore(ctx context.Context, statuses []string, before time.Time, limit int64) ([]models.Meeting, error)
+	CountHostedBy(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	CountAttendedBy(ctx context.Context, userID primitive.ObjectID, before time.Time) (int64, error)
 }
 
 type meetingRepository struct {
@@ -35,8 +56,12 @@ func NewMeetingRepository(db *mongo.Database) MeetingRepository {
 }
 
 func (r *meetingRepository) Create(ctx context.Context, meeting *models.Meeting) error {
-	_, err := r.collection.InsertOne(ctx, meeting)
-	return err
+	result, err := r.collection.InsertOne(ctx, meeting)
+	if err != nil {
+		return err
+	}
+	meeting.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
 }
 
 func (r *meetingRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Meeting, error) {
@@ -51,7 +76,39 @@ func (r *meetingRepository) FindByID(ctx context.Context, id primitive.ObjectID)
 	return &meeting, nil
 }
 
-func (r *meetingRepository) FindNearby(ctx context.Context, lon, lat float64, radiusMeter float64, days []int) ([]models.Meeting, error) {
+// 참여자 정보를 한 번의 $lookup으로 붙여서 가져옴. 순서는 참여 순서가 아니라서 서비스에서 맞춤
+func (r *meetingRepository) FindDetailByID(ctx context.Context, id primitive.ObjectID) (*models.MeetingDetail, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": id}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "users",
+			"let":  bson.M{"ids": "$participant_ids"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$in": bson.A{"$_id", "$$ids"}}}},
+				bson.M{"$project": bson.M{"nickname": 1, "profile_uri": 1, "level": 1}},
+			},
+			"as": "participants",
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		return nil, cursor.Err()
+	}
+
+	var detail models.MeetingDetail
+	if err := cursor.Decode(&detail); err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+func (r *meetingRepository) FindNearby(ctx context.Context, lon, lat float64, radiusMeter float64, days []int, eligibleFor *models.User) ([]models.Meeting, error) {
 	var meetings []models.Meeting
 
 	// 몽고디비의 개쩌는 공간 쿼리
@@ -65,12 +122,32 @@ func (r *meetingRepository) FindNearby(ctx context.Context, lon, lat float64, ra
 				"$maxDistance": radiusMeter,
 			},
 		},
+		// 이미 시작했거나 끝난 모임은 참여할 수 없으니 안 보여줌
+		"status": bson.M{"$in": []string{models.MeetingStatusRecruiting, models.MeetingStatusFull}},
 	}
 
 	if len(days) > 0 {
 		filter["day_of_week"] = bson.M{"$in": days}
 	}
 
+	// Meeting.AllowsAge, AllowsGender와 같은 조건
+	if eligibleFor != nil {
+		filter["banned_ids"] = bson.M{"$ne": eligibleFor.ID}
+		filter["age_range.0"] = bson.M{"$lte": eligibleFor.Age}
+
+		genderRule := bson.M{"gender_quota": bson.M{"$exists": false}}
+		if field, ok := genderQuotaFields[eligibleFor.Gender]; ok {
+			genderRule = bson.M{"$or": bson.A{genderRule, bson.M{"gender_quota." + field: bson.M{"$gt": 0}}}}
+		}
+		filter["$and"] = bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"age_range.1": bson.M{"$lte": 0}},
+				bson.M{"age_range.1": bson.M{"$gte": eligibleFor.Age}},
+			}},
+			genderRule,
+		}
+	}
+
 	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -83,14 +160,15 @@ func (r *meetingRepository) FindNearby(ctx context.Context, lon, lat float64, ra
 	return meetings, nil
 }
 
-func (r *meetingRepository) AddParticipant(ctx context.Context, meetingID primitive.ObjectID, userID primitive.ObjectID, maxParticipants int) (bool, error) {
+func (r *meetingRepository) AddParticipant(ctx context.Context, meetingID primitive.ObjectID, userID primitive.ObjectID, gender string) (bool, error) {
 	filter := bson.M{
-		"_id":    meetingID,
-		"status": models.MeetingStatusRecruiting,
-		"participants." + strconv.Itoa(maxParticipants-1): bson.M{"$exists": false}, // 마지막 원소가 있는지 확인 -> 정원 초과 여부를 확인할 수 있음
-		"participants": bson.M{"$ne": userID},
+		"_id":             meetingID,
+		"status":          models.MeetingStatusRecruiting,
+		"participant_ids": bson.M{"$ne": userID},
+		"banned_ids":      bson.M{"$ne": userID},
+		"$and":            bson.A{hasOpenSlotFilter(), genderQuotaFilter(gender), noWaitersAheadFilter(gender)}, // 대기자가 가져갈 수 있는 빈자리면 대기자 몫
 	}
-	update := bson.M{"$addToSet": bson.M{"participants": userID}}
+	update := addParticipantUpdate(userID, gender)
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -100,25 +178,161 @@ func (r *meetingRepository) AddParticipant(ctx context.Context, meetingID primit
 		return false, nil
 	}
 
+	r.markFullIfNeeded(ctx, meetingID)
+	return true, nil
+}
+
+// 대기열 맨 앞 사람을 참여시키면서 대기자 수를 같이 줄임.
+// 대기자 수는 줄을 설 때의 성별로, 성별 정원은 지금 성별로 확인함
+func (r *meetingRepository) PromoteFromWaitlist(ctx context.Context, entry *models.WaitlistEntry, gender string) (bool, error) {
+	filter := bson.M{
+		"_id":             entry.MeetingID,
+		"status":          models.MeetingStatusRecruiting,
+		"participant_ids": bson.M{"$ne": entry.UserID},
+		"banned_ids":      bson.M{"$ne": entry.UserID},
+		"$and":            bson.A{hasOpenSlotFilter(), genderQuotaFilter(gender)},
+	}
+	update := addParticipantUpdate(entry.UserID, gender)
+	update["$inc"] = waitlistCountInc(entry.Gender, -1)
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	if result.ModifiedCount == 0 {
+		return false, nil
+	}
+
+	r.markFullIfNeeded(ctx, entry.MeetingID)
+	return true, nil
+}
+
+func (r *meetingRepository) IncrementWaitlistCount(ctx context.Context, meetingID primitive.ObjectID, gender string, delta int) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": meetingID},
+		bson.M{"$inc": waitlistCountInc(gender, delta)},
+	)
+	return err
+}
+
+// 대기열을 통째로 비운 뒤에 호출
+func (r *meetingRepository) ResetWaitlistCount(ctx context.Context, meetingID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": meetingID},
+		bson.M{
+			"$set":   bson.M{"waitlist_count": 0},
+			"$unset": bson.M{"waitlist_genders": ""},
+		},
+	)
+	return err
+}
+
+// 전체 대기자 수와 성별별 대기자 수를 같이 맞춤
+func waitlistCountInc(gender string, delta int) bson.M {
+	inc := bson.M{"waitlist_count": delta}
+	if _, ok := genderQuotaFields[gender]; ok {
+		inc["waitlist_genders."+gender] = delta
+	}
+	return inc
+}
+
+var genderQuotaFields = map[string]string{
+	models.GenderMale:   "male",
+	models.GenderFemale: "female",
+}
+
+var participantCount = bson.M{"$size": bson.M{"$ifNull": bson.A{"$participant_ids", bson.A{}}}}
+
+// 저장된 정원과 비교해야 호출한 쪽이 읽은 뒤에 정원이 줄어도 넘치지 않음
+func hasOpenSlotFilter() bson.M {
+	return bson.M{"$expr": bson.M{"$lt": bson.A{participantCount, "$max_participants"}}}
+}
+
+// 성별 정원이 있는 모임이면 그 성별 자리가 남아 있어야 함. 성별을 모르면 정원 없는 모임만 통과
+func genderQuotaFilter(gender string) bson.M {
+	field, ok := genderQuotaFields[gender]
+	if !ok {
+		return bson.M{"gender_quota": bson.M{"$exists": false}}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"gender_quota": bson.M{"$exists": false}},
+		bson.M{"$expr": bson.M{"$lt": bson.A{participantsOf(gender), "$gender_quota." + field}}},
+	}}
+}
+
+// Meeting.HasWaitersAhead와 맞춰야 함.
+// 성별 정원이 없으면 대기자가 한 명이라도 있으면 막고, 있으면 자리가 남은 성별의 대기자만 따짐
+func noWaitersAheadFilter(gender string) bson.M {
+	noQuota := bson.M{
+		"gender_quota":   bson.M{"$exists": false},
+		"waitlist_count": bson.M{"$not": bson.M{"$gt": 0}},
+	}
+	if _, ok := genderQuotaFields[gender]; !ok {
+		return noQuota
+	}
+
+	conds := bson.A{bson.M{"$lte": bson.A{waitersOf(gender), 0}}}
+	for other, field := range genderQuotaFields {
+		if other == gender {
+			continue
+		}
+		conds = append(conds, bson.M{"$or": bson.A{
+			bson.M{"$lte": bson.A{waitersOf(other), 0}},
+			bson.M{"$gte": bson.A{participantsOf(other), "$gender_quota." + field}},
+		}})
+	}
+
+	return bson.M{"$or": bson.A{
+		noQuota,
+		bson.M{
+			"gender_quota": bson.M{"$exists": true},
+			"$expr":        bson.M{"$and": conds},
+		},
+	}}
+}
+
+func waitersOf(gender string) bson.M {
+	return bson.M{"$ifNull": bson.A{"$waitlist_genders." + gender, 0}}
+}
+
+func participantsOf(gender string) bson.M {
+	return bson.M{"$size": bson.M{"$filter": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$participant_genders", bson.A{}}},
+		"cond":  bson.M{"$eq": bson.A{"$$this.gender", gender}},
+	}}}
+}
+
+func addParticipantUpdate(userID primitive.ObjectID, gender string) bson.M {
+	update := bson.M{"$addToSet": bson.M{"participant_ids": userID}}
+	if gender != "" {
+		update["$push"] = bson.M{"participant_genders": models.ParticipantGender{UserID: userID, Gender: gender}}
+	}
+	return update
+}
+
+// 참여자가 늘어서 정원이 찼으면 마감으로. 참여 자체는 이미 성공했으니 실패해도 기록만
+func (r *meetingRepository) markFullIfNeeded(ctx context.Context, meetingID primitive.ObjectID) {
 	fullFilter := bson.M{
 		"_id":    meetingID,
 		"status": models.MeetingStatusRecruiting,
-		"participants." + strconv.Itoa(maxParticipants-1): bson.M{"$exists": true},
+		"$expr":  bson.M{"$gte": bson.A{participantCount, "$max_participants"}},
 	}
 	fullUpdate := bson.M{"$set": bson.M{"status": models.MeetingStatusFull}}
-	_, err = r.collection.UpdateOne(ctx, fullFilter, fullUpdate)
+	_, err := r.collection.UpdateOne(ctx, fullFilter, fullUpdate)
 	if err != nil {
 		log.Println("Successfully added user to meeting, but error occurred while updating status to full:", err)
 	}
-
-	return true, nil
 }
 
-func (r *meetingRepository) RemoveParticipant(ctx context.Context, meetingID primitive.ObjectID, userID primitive.ObjectID, maxParticipants int) (bool, error) {
+func (r *meetingRepository) RemoveParticipant(ctx context.Context, meetingID primitive.ObjectID, userID primitive.ObjectID) (bool, error) {
 	result, err := r.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": meetingID},
-		bson.M{"$pull": bson.M{"participants": userID}},
+		bson.M{"$pull": bson.M{
+			"participant_ids":     userID,
+			"co_host_ids":         userID,
+			"participant_genders": bson.M{"user_id": userID},
+		}},
 	)
 	if err != nil {
 		return false, err
@@ -130,7 +344,7 @@ func (r *meetingRepository) RemoveParticipant(ctx context.Context, meetingID pri
 	backFilter := bson.M{
 		"_id":    meetingID,
 		"status": models.MeetingStatusFull,
-		"participants." + strconv.Itoa(maxParticipants-1): bson.M{"$exists": false},
+		"$expr":  bson.M{"$lt": bson.A{participantCount, "$max_participants"}},
 	}
 	backUpdate := bson.M{"$set": bson.M{"status": models.MeetingStatusRecruiting}}
 
@@ -176,3 +390,217 @@ func (r *meetingRepository) DecrementSaveCount(ctx context.Context, meetingID pr
 
 	return nil
 }
+
+// statuses가 비어 있으면 상태와 관계없이 전부
+func (r *meetingRepository) FindByParticipant(ctx context.Context, userID primitive.ObjectID, statuses []string) ([]models.Meeting, error) {
+	filter := bson.M{"participant_ids": userID}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var meetings []models.Meeting
+	if err := cursor.All(ctx, &meetings); err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+func (r *meetingRepository) FindBySeries(ctx context.Context, seriesID primitive.ObjectID, afterOccurrence int, statuses []string) ([]models.Meeting, error) {
+	filter := bson.M{
+		"series_id":  seriesID,
+		"occurrence": bson.M{"$gt": afterOccurrence},
+	}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "occurrence", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var meetings []models.Meeting
+	if err := cursor.All(ctx, &meetings); err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// 시리즈의 어느 회차에든 참여한 적이 있는지
+func (r *meetingRepository) ExistsSeriesParticipant(ctx context.Context, seriesID, userID primitive.ObjectID) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx,
+		bson.M{"series_id": seriesID, "participant_ids": userID},
+		options.Count().SetLimit(1),
+	)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// 현재 상태가 fromStatuses 중 하나일 때만 바꿈
+func (r *meetingRepository) UpdateStatus(ctx context.Context, meetingID primitive.ObjectID, fromStatuses []string, status string) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":    meetingID,
+			"status": bson.M{"$in": fromStatuses},
+		},
+		bson.M{"$set": bson.M{"status": status}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// 새 방장은 이미 참여자여야 함. 공동 방장이었다면 공동 방장 목록에서는 빠짐
+func (r *meetingRepository) TransferHost(ctx context.Context, meetingID, fromID, toID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":             meetingID,
+			"host_id":         fromID,
+			"participant_ids": toID,
+		},
+		bson.M{
+			"$set":  bson.M{"host_id": toID},
+			"$pull": bson.M{"co_host_ids": toID},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// 취소된 모임은 세지 않음
+func (r *meetingRepository) CountHostedBy(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{
+		"host_id": userID,
+		"status":  bson.M{"$ne": models.MeetingStatusCancelled},
+	})
+}
+
+// 방장이 아닌 참여자로서 모임 시간이 지난 모임 수
+func (r *meetingRepository) CountAttendedBy(ctx context.Context, userID primitive.ObjectID, before time.Time) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{
+		"participant_ids": userID,
+		"host_id":         bson.M{"$ne": userID},
+		"meeting_time":    bson.M{"$lt": before},
+		"status":          bson.M{"$ne": models.MeetingStatusCancelled},
+	})
+}
+
+// 모집 중이거나 마감된 모임만 방장이나 공동 방장이 수정 가능
+// maxParticipants가 0보다 크면 정원도 바꾸는 것이라 현재 인원이 새 정원 이하일 때만 수정하고 모집 상태도 다시 맞춤
+func (r *meetingRepository) Update(ctx context.Context, meetingID, editorID primitive.ObjectID, updates bson.M, maxParticipants int) (bool, error) {
+	filter := bson.M{
+		"_id":    meetingID,
+		"$or":    []bson.M{{"host_id": editorID}, {"co_host_ids": editorID}},
+		"status": bson.M{"$in": []string{models.MeetingStatusRecruiting, models.MeetingStatusFull}},
+	}
+	if maxParticipants > 0 {
+		filter["participant_ids."+strconv.Itoa(maxParticipants)] = bson.M{"$exists": false}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": updates})
+	if err != nil {
+		return false, err
+	}
+	if result.MatchedCount == 0 {
+		return false, nil
+	}
+
+	if maxParticipants > 0 {
+		lastIndex := "participant_ids." + strconv.Itoa(maxParticipants-1)
+
+		_, err = r.collection.UpdateOne(ctx,
+			bson.M{"_id": meetingID, "status": models.MeetingStatusRecruiting, lastIndex: bson.M{"$exists": true}},
+			bson.M{"$set": bson.M{"status": models.MeetingStatusFull}},
+		)
+		if err != nil {
+			log.Println("Successfully updated meeting, but error occurred while updating status to full:", err)
+		}
+
+		_, err = r.collection.UpdateOne(ctx,
+			bson.M{"_id": meetingID, "status": models.MeetingStatusFull, lastIndex: bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"status": models.MeetingStatusRecruiting}},
+		)
+		if err != nil {
+			log.Println("Successfully updated meeting, but error occurred while updating status to recruiting:", err)
+		}
+	}
+
+	return true, nil
+}
+
+// 모임 시간이 before 이전인 모임을 오래된 순으로. 상태 전환 스케줄러가 사용
+func (r *meetingRepository) FindByStatusBefore(ctx context.Context, statuses []string, before time.Time, limit int64) ([]models.Meeting, error) {
+	filter := bson.M{
+		"status":       bson.M{"$in": statuses},
+		"meeting_time": bson.M{"$lte": before},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "meeting_time", Value: 1}}).
+		SetLimit(limit).
+		SetProjection(bson.M{"_id": 1, "status": 1, "meeting_time": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var meetings []models.Meeting
+	if err := cursor.All(ctx, &meetings); err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// 방장만 지정 가능. 대상은 방장이 아닌 참여자여야 함
+func (r *meetingRepository) AddCoHost(ctx context.Context, meetingID, hostID, userID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":             meetingID,
+			"host_id":         bson.M{"$eq": hostID, "$ne": userID},
+			"participant_ids": userID,
+		},
+		bson.M{"$addToSet": bson.M{"co_host_ids": userID}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *meetingRepository) RemoveCoHost(ctx context.Context, meetingID, userID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": meetingID},
+		bson.M{"$pull": bson.M{"co_host_ids": userID}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *meetingRepository) Ban(ctx context.Context, meetingID, userID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": meetingID},
+		bson.M{"$addToSet": bson.M{"banned_ids": userID}},
+	)
+	return err
+}
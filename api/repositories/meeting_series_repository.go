@@ -0,0 +1,163 @@
+// api/repositories/meeting_series_repository.go
+
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MeetingSeriesRepository interface {
+	Create(ctx context.Context, series *models.MeetingSeries) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.MeetingSeries, error)
+	// 다음 회차 시각이 before 이전인 진행 중 시리즈
+	FindDue(ctx context.Context, before time.Time, limit int64) ([]models.MeetingSeries, error)
+	// 처리한 회차 수가 from일 때만 한 회차 넘김. 여러 번 실행돼도 같은 회차를 두 번 넘기지 않음
+	Advance(ctx context.Context, seriesID primitive.ObjectID, from int, next time.Time, ended bool) (bool, error)
+	// 방장 포함 정원을 넘지 않을 때만 추가
+	AddMember(ctx context.Context, seriesID, userID primitive.ObjectID, maxParticipants int) (bool, error)
+	RemoveMember(ctx context.Context, seriesID, userID primitive.ObjectID) (bool, error)
+	Ban(ctx context.Context, seriesID, userID primitive.ObjectID) error
+	// 조회한 뒤로 새 회차가 만들어지지 않았을 때만 틀을 바꿈. 그 사이 만들어진 회차가 옛 틀로 남지 않게 함
+	Update(ctx context.Context, seriesID primitive.ObjectID, materialized int, updates bson.M) (bool, error)
+	EndByHost(ctx context.Context, hostID primitive.ObjectID) error
+	RemoveMemberFromAll(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type meetingSeriesRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMeetingSeriesRepository(db *mongo.Database) MeetingSeriesRepository {
+	return &meetingSeriesRepository{collection: db.Collection("meeting_series")}
+}
+
+func (r *meetingSeriesRepository) Create(ctx context.Context, series *models.MeetingSeries) error {
+	result, err := r.collection.InsertOne(ctx, series)
+	if err != nil {
+		return err
+	}
+	series.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *meetingSeriesRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.MeetingSeries, error) {
+	var series models.MeetingSeries
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&series)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *meetingSeriesRepository) FindDue(ctx context.Context, before time.Time, limit int64) ([]models.MeetingSeries, error) {
+	filter := bson.M{
+		"status":          models.SeriesStatusActive,
+		"next_occurrence": bson.M{"$lte": before},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_occurrence", Value: 1}}).SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var series []models.MeetingSeries
+	if err := cursor.All(ctx, &series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (r *meetingSeriesRepository) Advance(ctx context.Context, seriesID primitive.ObjectID, from int, next time.Time, ended bool) (bool, error) {
+	set := bson.M{"next_occurrence": next}
+	if ended {
+		set["status"] = models.SeriesStatusEnded
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": seriesID, "status": models.SeriesStatusActive, "materialized": from},
+		bson.M{"$set": set, "$inc": bson.M{"materialized": 1}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *meetingSeriesRepository) AddMember(ctx context.Context, seriesID, userID primitive.ObjectID, maxParticipants int) (bool, error) {
+	filter := bson.M{
+		"_id":     seriesID,
+		"status":  models.SeriesStatusActive,
+		"host_id": bson.M{"$ne": userID},
+		"member_ids." + strconv.Itoa(maxParticipants-2): bson.M{"$exists": false}, // 방장 자리를 빼고 정원 확인
+		"member_ids": bson.M{"$ne": userID},
+		"banned_ids": bson.M{"$ne": userID},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$addToSet": bson.M{"member_ids": userID}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *meetingSeriesRepository) RemoveMember(ctx context.Context, seriesID, userID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": seriesID},
+		bson.M{"$pull": bson.M{"member_ids": userID}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *meetingSeriesRepository) Ban(ctx context.Context, seriesID, userID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": seriesID},
+		bson.M{
+			"$pull":     bson.M{"member_ids": userID},
+			"$addToSet": bson.M{"banned_ids": userID},
+		},
+	)
+	return err
+}
+
+func (r *meetingSeriesRepository) Update(ctx context.Context, seriesID primitive.ObjectID, materialized int, updates bson.M) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": seriesID, "status": models.SeriesStatusActive, "materialized": materialized},
+		bson.M{"$set": updates},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *meetingSeriesRepository) EndByHost(ctx context.Context, hostID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"host_id": hostID, "status": models.SeriesStatusActive},
+		bson.M{"$set": bson.M{"status": models.SeriesStatusEnded}},
+	)
+	return err
+}
+
+func (r *meetingSeriesRepository) RemoveMemberFromAll(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"member_ids": userID},
+		bson.M{"$pull": bson.M{"member_ids": userID}},
+	)
+	return err
+}
@@ -0,0 +1,74 @@
+// api/repositories/notification_repository.go
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NotificationRepository interface {
+	CreateMany(ctx context.Context, notifications []models.Notification) error
+	ListByUser(ctx context.Context, userID primitive.ObjectID, limit int64) ([]models.Notification, error)
+	MarkAllRead(ctx context.Context, userID primitive.ObjectID, now time.Time) error
+	DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type notificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationRepository(db *mongo.Database) NotificationRepository {
+	return &notificationRepository{collection: db.Collection("notifications")}
+}
+
+func (r *notificationRepository) CreateMany(ctx context.Context, notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(notifications))
+	for i := range notifications {
+		docs[i] = notifications[i]
+	}
+
+	// 한 명한테 실패해도 나머지는 넣음
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// 최신순
+func (r *notificationRepository) ListByUser(ctx context.Context, userID primitive.ObjectID, limit int64) ([]models.Notification, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []models.Notification
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID primitive.ObjectID, now time.Time) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "read_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"read_at": now}},
+	)
+	return err
+}
+
+func (r *notificationRepository) DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,45 @@
+// api/repositories/revocation_repository.go
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RevocationRepository interface {
+	Create(ctx context.Context, revocation *models.Revocation) error
+	FindActive(ctx context.Context) ([]models.Revocation, error)
+}
+
+type revocationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRevocationRepository(db *mongo.Database) RevocationRepository {
+	return &revocationRepository{collection: db.Collection("revocations")}
+}
+
+func (r *revocationRepository) Create(ctx context.Context, revocation *models.Revocation) error {
+	_, err := r.collection.InsertOne(ctx, revocation)
+	return err
+}
+
+// TTL 모니터는 1분 주기라서 만료 시각으로 한 번 더 거름
+func (r *revocationRepository) FindActive(ctx context.Context) ([]models.Revocation, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"expires_at": bson.M{"$gt": time.Now()}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var revocations []models.Revocation
+	if err := cursor.All(ctx, &revocations); err != nil {
+		return nil, err
+	}
+	return revocations, nil
+}
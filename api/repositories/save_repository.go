@@ -9,11 +9,15 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type SaveRepository interface {
 	Create(ctx context.Context, save *models.Save) error
 	Delete(ctx context.Context, userID, meetingID primitive.ObjectID) (int64, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Save, error)
+	Exists(ctx context.Context, userID, meetingID primitive.ObjectID) (bool, error)
+	ListUserIDsByMeeting(ctx context.Context, meetingID primitive.ObjectID) ([]primitive.ObjectID, error)
 }
 
 type saveRepository struct {
@@ -39,3 +43,49 @@ func (r *saveRepository) Delete(ctx context.Context, userID, meetingID primitive
 	}
 	return result.DeletedCount, nil
 }
+
+func (r *saveRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Save, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var saves []models.Save
+	if err := cursor.All(ctx, &saves); err != nil {
+		return nil, err
+	}
+	return saves, nil
+}
+
+func (r *saveRepository) Exists(ctx context.Context, userID, meetingID primitive.ObjectID) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"user_id":    userID,
+		"meeting_id": meetingID,
+	}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// 모임을 저장한 유저들. 알림 보낼 때 사용
+func (r *saveRepository) ListUserIDsByMeeting(ctx context.Context, meetingID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	opts := options.Find().SetProjection(bson.M{"user_id": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"meeting_id": meetingID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var saves []models.Save
+	if err := cursor.All(ctx, &saves); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(saves))
+	for _, save := range saves {
+		ids = append(ids, save.UserID)
+	}
+	return ids, nil
+}
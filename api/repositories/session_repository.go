@@ -0,0 +1,116 @@
+// api/repositories/session_repository.go
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Session, error)
+	ListActiveByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Session, error)
+	Touch(ctx context.Context, id primitive.ObjectID, ip string, expiresAt time.Time) error
+	Revoke(ctx context.Context, id, userID primitive.ObjectID) (bool, error)
+	RevokeAllByUser(ctx context.Context, userID primitive.ObjectID) error
+	DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type sessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSessionRepository(db *mongo.Database) SessionRepository {
+	return &sessionRepository{collection: db.Collection("sessions")}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
+	if session.ID.IsZero() {
+		session.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, session)
+	return err
+}
+
+func (r *sessionRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Session, error) {
+	var session models.Session
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&session)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Session, error) {
+	// TTL 삭제는 바로 일어나지 않으므로 만료된 세션은 여기서도 거름
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []models.Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id primitive.ObjectID, ip string, expiresAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"last_seen_ip": ip, "last_seen_at": time.Now(), "expires_at": expiresAt}},
+	)
+	return err
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id, userID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":        id,
+			"user_id":    userID,
+			"revoked_at": bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *sessionRepository) RevokeAllByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"user_id":    userID,
+			"revoked_at": bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *sessionRepository) DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,48 @@
+// api/repositories/signing_key_repository.go
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SigningKeyRepository interface {
+	Create(ctx context.Context, key *models.SigningKey) error
+	FindUnexpired(ctx context.Context, now time.Time) ([]models.SigningKey, error)
+}
+
+type signingKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSigningKeyRepository(db *mongo.Database) SigningKeyRepository {
+	return &signingKeyRepository{collection: db.Collection("signing_keys")}
+}
+
+// activates_at 유니크 인덱스 때문에 여러 인스턴스가 동시에 같은 차례의 키를 만들면 하나만 성공함
+func (r *signingKeyRepository) Create(ctx context.Context, key *models.SigningKey) error {
+	_, err := r.collection.InsertOne(ctx, key)
+	return err
+}
+
+func (r *signingKeyRepository) FindUnexpired(ctx context.Context, now time.Time) ([]models.SigningKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "activates_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"expires_at": bson.M{"$gt": now}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []models.SigningKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
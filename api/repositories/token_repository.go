@@ -0,0 +1,106 @@
+// api/repositories/token_repository.go
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error)
+	RevokeFamily(ctx context.Context, familyID primitive.ObjectID) error
+	RevokeAllByUser(ctx context.Context, userID primitive.ObjectID) error
+	RevokeAllBySession(ctx context.Context, sessionID primitive.ObjectID) error
+	DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type refreshTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRefreshTokenRepository(db *mongo.Database) RefreshTokenRepository {
+	return &refreshTokenRepository{collection: db.Collection("refresh_tokens")}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	_, err := r.collection.InsertOne(ctx, token)
+	return err
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// 아직 안 쓰인 토큰일 때만 사용 처리. 동시에 두 번 refresh 요청이 와도 하나만 성공함
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":        id,
+			"used_at":    bson.M{"$exists": false},
+			"revoked_at": bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{"used_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"family_id":  familyID,
+			"revoked_at": bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *refreshTokenRepository) RevokeAllByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"user_id":    userID,
+			"revoked_at": bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *refreshTokenRepository) RevokeAllBySession(ctx context.Context, sessionID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"session_id": sessionID,
+			"revoked_at": bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *refreshTokenRepository) DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
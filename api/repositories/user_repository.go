@@ -4,11 +4,13 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/seojoonrp/bbiyong-backend/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserRepository interface {
@@ -16,6 +18,29 @@ type UserRepository interface {
 	FindByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
 	FindByUsername(ctx context.Context, username string) (*models.User, error)
 	CompleteProfile(ctx context.Context, id primitive.ObjectID, updates bson.M) (bool, error)
+	FindByIdentity(ctx context.Context, provider, socialID string) (*models.User, error)
+	BackfillIdentities(ctx context.Context, id primitive.ObjectID, identities []models.Identity) error
+	AddIdentity(ctx context.Context, id primitive.ObjectID, identity models.Identity, updates bson.M) (bool, error)
+	RemoveIdentity(ctx context.Context, id primitive.ObjectID, provider string, updates bson.M, unsets bson.M) (bool, error)
+	ScheduleDeletion(ctx context.Context, id primitive.ObjectID, scheduledAt time.Time, hostPolicy string) error
+	CancelDeletion(ctx context.Context, id primitive.ObjectID) (bool, error)
+	FindDueForDeletion(ctx context.Context, now time.Time, limit int64) ([]models.User, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	UpdateRole(ctx context.Context, id primitive.ObjectID, role string) (bool, error)
+	// 올린 뒤의 버전을 돌려줌. 이 값보다 낮은 버전으로 발급된 토큰은 폐기 대상
+	IncrementTokenVersion(ctx context.Context, id primitive.ObjectID) (int, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	SetEmail(ctx context.Context, id primitive.ObjectID, email string) error
+	MarkEmailVerified(ctx context.Context, id primitive.ObjectID, email string) (bool, error)
+	UpdatePassword(ctx context.Context, id primitive.ObjectID, hashedPassword string) error
+	SetPendingTwoFactor(ctx context.Context, id primitive.ObjectID, secret string) (bool, error)
+	EnableTwoFactor(ctx context.Context, id primitive.ObjectID, pendingSecret string, recoveryCodeHashes []string, step int64) (bool, error)
+	DisableTwoFactor(ctx context.Context, id primitive.ObjectID) error
+	UseTwoFactorStep(ctx context.Context, id primitive.ObjectID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error)
+	SetRecoveryCodes(ctx context.Context, id primitive.ObjectID, codeHashes []string) error
+	UpdateProfile(ctx context.Context, id primitive.ObjectID, updates bson.M, nicknameChangeableBefore *time.Time) (bool, error)
+	FindSummaries(ctx context.Context, ids []primitive.ObjectID, limit int64) ([]models.UserSummary, error)
 }
 
 type userRepository struct {
@@ -29,6 +54,9 @@ func NewUserRepository(db *mongo.Database) UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
 	_, err := r.collection.InsertOne(ctx, user)
 	return err
 }
@@ -75,3 +103,295 @@ func (r *userRepository) CompleteProfile(ctx context.Context, id primitive.Objec
 
 	return true, nil
 }
+
+func (r *userRepository) FindByIdentity(ctx context.Context, provider, socialID string) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"identities.key": models.IdentityKey(provider, socialID)}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// identities 필드가 생기기 전에 가입한 유저용. 비어 있을 때만 채움
+func (r *userRepository) BackfillIdentities(ctx context.Context, id primitive.ObjectID, identities []models.Identity) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":          id,
+			"identities.0": bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{"identities": identities}},
+	)
+	return err
+}
+
+// 같은 provider가 이미 연결되어 있으면 실패
+func (r *userRepository) AddIdentity(ctx context.Context, id primitive.ObjectID, identity models.Identity, updates bson.M) (bool, error) {
+	update := bson.M{"$push": bson.M{"identities": identity}}
+	if len(updates) > 0 {
+		update["$set"] = updates
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":                 id,
+			"identities.provider": bson.M{"$ne": identity.Provider},
+		},
+		update,
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// 로그인 수단이 두 개 이상 남아 있을 때만 제거. 마지막 수단은 지울 수 없음
+func (r *userRepository) RemoveIdentity(ctx context.Context, id primitive.ObjectID, provider string, updates bson.M, unsets bson.M) (bool, error) {
+	update := bson.M{"$pull": bson.M{"identities": bson.M{"provider": provider}}}
+	if len(updates) > 0 {
+		update["$set"] = updates
+	}
+	if len(unsets) > 0 {
+		update["$unset"] = unsets
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":                 id,
+			"identities.provider": provider,
+			"identities.1":        bson.M{"$exists": true},
+		},
+		update,
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *userRepository) ScheduleDeletion(ctx context.Context, id primitive.ObjectID, scheduledAt time.Time, hostPolicy string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"deletion_scheduled_at": scheduledAt,
+			"deletion_host_policy":  hostPolicy,
+		}},
+	)
+	return err
+}
+
+func (r *userRepository) CancelDeletion(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":                   id,
+			"deletion_scheduled_at": bson.M{"$exists": true},
+		},
+		bson.M{"$unset": bson.M{
+			"deletion_scheduled_at": "",
+			"deletion_host_policy":  "",
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *userRepository) FindDueForDeletion(ctx context.Context, now time.Time, limit int64) ([]models.User, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "deletion_scheduled_at", Value: 1}}).SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"deletion_scheduled_at": bson.M{"$lte": now}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *userRepository) UpdateRole(ctx context.Context, id primitive.ObjectID, role string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"role": role}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, id primitive.ObjectID) (int, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"token_version": 1})
+
+	var user models.User
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"token_version": 1}}, opts).Decode(&user)
+	if err != nil {
+		return 0, err
+	}
+	return user.TokenVersion, nil
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// 이메일을 바꾸면 다시 인증해야 함
+func (r *userRepository) SetEmail(ctx context.Context, id primitive.ObjectID, email string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"email": email, "email_verified": false}},
+	)
+	return err
+}
+
+// 인증 코드를 보낸 뒤 이메일이 바뀌었으면 인증하지 않음
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id primitive.ObjectID, email string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "email": email},
+		bson.M{"$set": bson.M{"email_verified": true}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id primitive.ObjectID, hashedPassword string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"password": hashedPassword}},
+	)
+	return err
+}
+
+// 이미 켜져 있으면 시크릿을 덮어쓰지 않음
+func (r *userRepository) SetPendingTwoFactor(ctx context.Context, id primitive.ObjectID, secret string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "two_factor.enabled": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{"two_factor": models.TwoFactor{PendingSecret: secret}}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// 확인한 시크릿이 그 사이 다른 setup 요청으로 바뀌지 않았을 때만 활성화
+func (r *userRepository) EnableTwoFactor(ctx context.Context, id primitive.ObjectID, pendingSecret string, recoveryCodeHashes []string, step int64) (bool, error) {
+	now := time.Now()
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "two_factor.enabled": bson.M{"$ne": true}, "two_factor.pending_secret": pendingSecret},
+		bson.M{"$set": bson.M{"two_factor": models.TwoFactor{
+			Enabled:            true,
+			Secret:             pendingSecret,
+			RecoveryCodeHashes: recoveryCodeHashes,
+			LastUsedStep:       step,
+			EnabledAt:          &now,
+		}}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *userRepository) DisableTwoFactor(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$unset": bson.M{"two_factor": ""}})
+	return err
+}
+
+// 이미 쓴 스텝 이하의 코드는 거절. 같은 코드를 두 번 제출해도 한 번만 통과함
+func (r *userRepository) UseTwoFactorStep(ctx context.Context, id primitive.ObjectID, step int64) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "two_factor.enabled": true, "two_factor.last_used_step": bson.M{"$lt": step}},
+		bson.M{"$set": bson.M{"two_factor.last_used_step": step}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *userRepository) UseRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "two_factor.enabled": true, "two_factor.recovery_code_hashes": codeHash},
+		bson.M{"$pull": bson.M{"two_factor.recovery_code_hashes": codeHash}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *userRepository) SetRecoveryCodes(ctx context.Context, id primitive.ObjectID, codeHashes []string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "two_factor.enabled": true},
+		bson.M{"$set": bson.M{"two_factor.recovery_code_hashes": codeHashes}},
+	)
+	return err
+}
+
+// 닉네임을 바꿀 때는 nicknameChangeableBefore 이전에 마지막으로 바꾼 경우에만 반영 (동시 요청으로 쿨다운 우회 방지)
+func (r *userRepository) UpdateProfile(ctx context.Context, id primitive.ObjectID, updates bson.M, nicknameChangeableBefore *time.Time) (bool, error) {
+	filter := bson.M{"_id": id, "is_profile_set": true}
+	if nicknameChangeableBefore != nil {
+		filter["$or"] = bson.A{
+			bson.M{"nickname_changed_at": bson.M{"$exists": false}},
+			bson.M{"nickname_changed_at": bson.M{"$lte": *nicknameChangeableBefore}},
+		}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": updates})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// 탈퇴 유예 중인 유저는 빼고 요약 정보만
+func (r *userRepository) FindSummaries(ctx context.Context, ids []primitive.ObjectID, limit int64) ([]models.UserSummary, error) {
+	filter := bson.M{
+		"_id":                   bson.M{"$in": ids},
+		"deletion_scheduled_at": bson.M{"$exists": false},
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"nickname": 1, "profile_uri": 1, "level": 1}).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var summaries []models.UserSummary
+	if err := cursor.All(ctx, &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
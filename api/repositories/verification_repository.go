@@ -0,0 +1,105 @@
+// api/repositories/verification_repository.go
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type VerificationTokenRepository interface {
+	Create(ctx context.Context, token *models.VerificationToken) error
+	FindLatest(ctx context.Context, userID primitive.ObjectID, purpose string) (*models.VerificationToken, error)
+	FindByHash(ctx context.Context, purpose, tokenHash string) (*models.VerificationToken, error)
+	IncrementAttempts(ctx context.Context, id primitive.ObjectID) error
+	MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error)
+	InvalidateAll(ctx context.Context, userID primitive.ObjectID, purpose string) error
+	DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type verificationTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewVerificationTokenRepository(db *mongo.Database) VerificationTokenRepository {
+	return &verificationTokenRepository{collection: db.Collection("verification_tokens")}
+}
+
+func (r *verificationTokenRepository) Create(ctx context.Context, token *models.VerificationToken) error {
+	if token.ID.IsZero() {
+		token.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, token)
+	return err
+}
+
+func (r *verificationTokenRepository) FindLatest(ctx context.Context, userID primitive.ObjectID, purpose string) (*models.VerificationToken, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var token models.VerificationToken
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID, "purpose": purpose}, opts).Decode(&token)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *verificationTokenRepository) FindByHash(ctx context.Context, purpose, tokenHash string) (*models.VerificationToken, error) {
+	var token models.VerificationToken
+	err := r.collection.FindOne(ctx, bson.M{"purpose": purpose, "token_hash": tokenHash}).Decode(&token)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *verificationTokenRepository) IncrementAttempts(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"attempts": 1}})
+	return err
+}
+
+// 아직 안 쓰였고 만료되지 않은 경우에만 사용 처리. 동시에 두 번 써도 한쪽만 성공함
+func (r *verificationTokenRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id":        id,
+		"used_at":    bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": now},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"used_at": now}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// 새로 발급하면 이전에 보낸 것들은 못 쓰게 함
+func (r *verificationTokenRepository) InvalidateAll(ctx context.Context, userID primitive.ObjectID, purpose string) error {
+	filter := bson.M{
+		"user_id": userID,
+		"purpose": purpose,
+		"used_at": bson.M{"$exists": false},
+	}
+
+	_, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"used_at": time.Now()}})
+	return err
+}
+
+func (r *verificationTokenRepository) DeleteAllByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,140 @@
+// api/repositories/waitlist_repository.go
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WaitlistRepository interface {
+	Create(ctx context.Context, entry *models.WaitlistEntry) error
+	Find(ctx context.Context, meetingID, userID primitive.ObjectID) (*models.WaitlistEntry, error)
+	// 맨 앞 사람을 꺼냄. genders가 있으면 그 성별 중에서만. 대기열이 비어 있으면 nil
+	PopFirst(ctx context.Context, meetingID primitive.ObjectID, genders []string) (*models.WaitlistEntry, error)
+	Delete(ctx context.Context, meetingID, userID primitive.ObjectID) (*models.WaitlistEntry, error)
+	CountAhead(ctx context.Context, entry *models.WaitlistEntry) (int64, error)
+	CountByMeeting(ctx context.Context, meetingID primitive.ObjectID) (int64, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.WaitlistEntry, error)
+	// 모임의 대기열을 비우고 빠진 유저를 돌려줌
+	DeleteAllByMeeting(ctx context.Context, meetingID primitive.ObjectID) ([]primitive.ObjectID, error)
+}
+
+type waitlistRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWaitlistRepository(db *mongo.Database) WaitlistRepository {
+	return &waitlistRepository{collection: db.Collection("waitlists")}
+}
+
+// 꺼냈다가 되돌려 놓을 때는 기존 ID와 시각을 그대로 넣어서 순서를 유지함
+func (r *waitlistRepository) Create(ctx context.Context, entry *models.WaitlistEntry) error {
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+func (r *waitlistRepository) Find(ctx context.Context, meetingID, userID primitive.ObjectID) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := r.collection.FindOne(ctx, bson.M{"meeting_id": meetingID, "user_id": userID}).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *waitlistRepository) PopFirst(ctx context.Context, meetingID primitive.ObjectID, genders []string) (*models.WaitlistEntry, error) {
+	filter := bson.M{"meeting_id": meetingID}
+	if genders != nil {
+		filter["gender"] = bson.M{"$in": genders}
+	}
+	opts := options.FindOneAndDelete().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	var entry models.WaitlistEntry
+	err := r.collection.FindOneAndDelete(ctx, filter, opts).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// 지운 항목을 돌려줌. 대기열에 없었으면 nil
+func (r *waitlistRepository) Delete(ctx context.Context, meetingID, userID primitive.ObjectID) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := r.collection.FindOneAndDelete(ctx, bson.M{"meeting_id": meetingID, "user_id": userID}).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// entry보다 앞에 있는 사람 수
+func (r *waitlistRepository) CountAhead(ctx context.Context, entry *models.WaitlistEntry) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{
+		"meeting_id": entry.MeetingID,
+		"$or": []bson.M{
+			{"created_at": bson.M{"$lt": entry.CreatedAt}},
+			{"created_at": entry.CreatedAt, "_id": bson.M{"$lt": entry.ID}},
+		},
+	})
+}
+
+func (r *waitlistRepository) CountByMeeting(ctx context.Context, meetingID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"meeting_id": meetingID})
+}
+
+func (r *waitlistRepository) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.WaitlistEntry, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.WaitlistEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *waitlistRepository) DeleteAllByMeeting(ctx context.Context, meetingID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"meeting_id": meetingID}, options.Find().SetProjection(bson.M{"user_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.WaitlistEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(entries))
+	userIDs := make([]primitive.ObjectID, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+		userIDs = append(userIDs, entry.UserID)
+	}
+
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
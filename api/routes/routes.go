@@ -8,6 +8,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/seojoonrp/bbiyong-backend/api/handlers"
 	"github.com/seojoonrp/bbiyong-backend/api/middleware"
+	"github.com/seojoonrp/bbiyong-backend/api/services"
+	"github.com/seojoonrp/bbiyong-backend/models"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -19,12 +21,25 @@ func SetupRoutes(
 	chatHandler *handlers.ChatHandler,
 	friendHandler *handlers.FriendHandler,
 	saveHandler *handlers.SaveHandler,
+	userHandler *handlers.UserHandler,
+	healthHandler *handlers.HealthHandler,
+	adminHandler *handlers.AdminHandler,
+	jwksHandler *handlers.JWKSHandler,
+	notificationHandler *handlers.NotificationHandler,
+	seriesHandler *handlers.MeetingSeriesHandler,
+	tokenService services.TokenService,
+	revocationService services.RevocationService,
+	userService services.UserService,
 ) {
+	// 다른 서비스가 bbiyong 토큰을 검증할 때 쓰는 공개키
+	router.GET("/.well-known/jwks.json", jwksHandler.GetKeys)
+
 	apiV1 := router.Group("/api/v1")
 	{
 		apiV1.GET("/ping", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, gin.H{"message": "bbiyong server is running!"})
 		})
+		apiV1.GET("/health", healthHandler.Check)
 
 		auth := apiV1.Group("/auth")
 		{
@@ -33,27 +48,95 @@ func SetupRoutes(
 			auth.POST("/google", authHandler.GoogleLogin)
 			auth.POST("/kakao", authHandler.KakaoLogin)
 			auth.POST("/apple", authHandler.AppleLogin)
+			auth.POST("/refresh", authHandler.Refresh)
 			auth.GET("/check-username", authHandler.CheckUsername)
+			auth.POST("/password/forgot", authHandler.ForgotPassword)
+			auth.POST("/password/reset", authHandler.ResetPassword)
+			auth.POST("/2fa/verify", authHandler.VerifyTwoFactor)
 		}
 
+		// 탈퇴 유예 중에도 쓸 수 있는 기능. 로그인한 뒤 복구하거나 로그아웃만 할 수 있음
 		protected := apiV1.Group("/")
-		protected.Use(middleware.AuthMiddleware())
+		protected.Use(middleware.AuthMiddleware(tokenService, revocationService))
 		{
-			protected.POST("/auth/profile", authHandler.SetProfile)
+			protected.POST("/auth/logout", authHandler.Logout)
+			protected.POST("/auth/logout-all", authHandler.LogoutAll)
+
+			protected.GET("/users/me", userHandler.GetMe)
+			protected.POST("/users/me/restore", userHandler.RestoreMe)
+			protected.GET("/users/me/sessions", userHandler.ListSessions)
+			protected.DELETE("/users/me/sessions/:id", userHandler.RevokeSession)
+		}
 
-			protected.POST("/meetings", meetingHandler.CreateMeeting)
-			protected.GET("/meetings/nearby", meetingHandler.GetNearby)
-			protected.POST("/meetings/:id/join", meetingHandler.Join)
-			protected.POST("/meetings/:id/leave", meetingHandler.Leave)
-			protected.POST("/meetings/:id/save", saveHandler.SaveMeeting)
-			protected.DELETE("/meetings/:id/save", saveHandler.UnsaveMeeting)
+		active := protected.Group("/")
+		active.Use(middleware.RequireActiveAccount(userService))
+		{
+			active.POST("/auth/profile", authHandler.SetProfile)
+			active.POST("/auth/email/verification", authHandler.ResendEmailVerification)
+			active.POST("/auth/email/verify", authHandler.VerifyEmail)
+			active.POST("/auth/2fa/setup", authHandler.SetupTwoFactor)
+			active.POST("/auth/2fa/enable", authHandler.EnableTwoFactor)
+			active.POST("/auth/2fa/disable", authHandler.DisableTwoFactor)
+			active.POST("/auth/2fa/recovery-codes", authHandler.RegenerateRecoveryCodes)
 
-			protected.GET("/ws/meetings/:id", chatHandler.ChatConnect)
-			protected.GET("/meetings/:id/chats", chatHandler.GetChatHistory)
+			active.PATCH("/users/me", userHandler.UpdateMe)
+			active.DELETE("/users/me", userHandler.DeleteMe)
+			active.PUT("/users/me/email", authHandler.ChangeEmail)
+			active.POST("/users/me/identities/local", authHandler.LinkLocalCredentials)
+			active.POST("/users/me/identities/:provider", authHandler.LinkSocialIdentity)
+			active.DELETE("/users/me/identities/:provider", authHandler.UnlinkIdentity)
 
-			protected.POST("/users/:id/friend", friendHandler.RequestFriend)
-			protected.PATCH("/friendships/:id/accept", friendHandler.AcceptFriend)
-			protected.GET("/friends", friendHandler.GetFriendList)
+			active.GET("/notifications", notificationHandler.ListNotifications)
+			active.POST("/notifications/read", notificationHandler.MarkAllRead)
+		}
+
+		// 온보딩(프로필 설정)을 끝낸 유저만 쓸 수 있는 기능
+		member := active.Group("/")
+		member.Use(middleware.RequireCompleteProfile(userService))
+		{
+			member.POST("/meetings", meetingHandler.CreateMeeting)
+			member.GET("/meetings/nearby", meetingHandler.GetNearby)
+			member.GET("/meetings/:id", meetingHandler.GetMeeting)
+			member.PATCH("/meetings/:id", meetingHandler.UpdateMeeting)
+			member.POST("/meetings/:id/cancel", meetingHandler.CancelMeeting)
+			member.POST("/meetings/:id/host", meetingHandler.TransferHost)
+			member.POST("/meetings/:id/co-hosts/:userID", meetingHandler.AddCoHost)
+			member.DELETE("/meetings/:id/co-hosts/:userID", meetingHandler.RemoveCoHost)
+			member.POST("/meetings/:id/participants/:userID/kick", meetingHandler.KickParticipant)
+			member.POST("/meetings/:id/join", meetingHandler.Join)
+			member.POST("/meetings/:id/leave", meetingHandler.Leave)
+			member.GET("/meetings/:id/join-requests", meetingHandler.ListJoinRequests)
+			member.DELETE("/meetings/:id/join-requests/me", meetingHandler.CancelJoinRequest)
+			member.POST("/meetings/:id/join-requests/:requestID/approve", meetingHandler.ApproveJoinRequest)
+			member.POST("/meetings/:id/join-requests/:requestID/reject", meetingHandler.RejectJoinRequest)
+			member.POST("/meetings/:id/waitlist", meetingHandler.JoinWaitlist)
+			member.DELETE("/meetings/:id/waitlist", meetingHandler.LeaveWaitlist)
+			member.GET("/meetings/:id/waitlist/me", meetingHandler.GetWaitlistPosition)
+			member.POST("/meetings/:id/save", saveHandler.SaveMeeting)
+			member.DELETE("/meetings/:id/save", saveHandler.UnsaveMeeting)
+
+			member.POST("/series", seriesHandler.CreateSeries)
+			member.GET("/series/:id", seriesHandler.GetSeries)
+			member.POST("/series/:id/members", seriesHandler.OptIn)
+			member.DELETE("/series/:id/members/me", seriesHandler.OptOut)
+
+			member.GET("/ws/meetings/:id", chatHandler.ChatConnect)
+			member.GET("/meetings/:id/chats", chatHandler.GetChatHistory)
+
+			member.GET("/users/:id", userHandler.GetUser)
+			member.POST("/users/:id/friend", friendHandler.RequestFriend)
+			member.PATCH("/friendships/:id/accept", friendHandler.AcceptFriend)
+			member.GET("/friends", friendHandler.GetFriendList)
+		}
+
+		// 운영자 전용. 그룹 전체는 모더레이터 이상, 역할 변경과 감사 기록은 관리자만
+		admin := apiV1.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(tokenService, revocationService), middleware.RequireActiveAccount(userService), middleware.RequireRole(models.RoleModerator))
+		{
+			admin.GET("/users/:id", adminHandler.GetUser)
+			admin.POST("/users/:id/revoke-sessions", adminHandler.RevokeUserSessions)
+			admin.PATCH("/users/:id/role", middleware.RequireRole(models.RoleAdmin), adminHandler.ChangeRole)
+			admin.GET("/audit-logs", middleware.RequireRole(models.RoleAdmin), adminHandler.ListAuditLogs)
 		}
 	}
 }
@@ -0,0 +1,318 @@
+// api/services/account_service.go
+
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 웹소켓 허브가 구현함. ws 패키지가 services를 import하므로 여기서는 인터페이스로만 받음
+type ConnectionManager interface {
+	DisconnectUser(userID string)
+	DisconnectUserFromMeeting(userID, meetingID string)
+}
+
+type AccountService interface {
+	RequestDeletion(ctx context.Context, userID string, req models.DeleteAccountRequest) (*time.Time, error)
+	CancelDeletion(ctx context.Context, userID string) error
+	PurgeDueAccounts(ctx context.Context) (int, error)
+}
+
+type accountService struct {
+	userRepo         repositories.UserRepository
+	meetingRepo      repositories.MeetingRepository
+	friendRepo       repositories.FriendRepository
+	saveRepo         repositories.SaveRepository
+	chatRepo         repositories.ChatRepository
+	sessionRepo      repositories.SessionRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	verificationRepo repositories.VerificationTokenRepository
+	notificationRepo repositories.NotificationRepository
+	joinRequestRepo  repositories.JoinRequestRepository
+	seriesRepo       repositories.MeetingSeriesRepository
+	sessionService   SessionService
+	meetings         MeetingService
+	waitlist         WaitlistService
+	connections      ConnectionManager
+	eventChan        chan<- models.MeetingEvent
+}
+
+func NewAccountService(
+	ur repositories.UserRepository,
+	mr repositories.MeetingRepository,
+	fr repositories.FriendRepository,
+	sr repositories.SaveRepository,
+	cr repositories.ChatRepository,
+	sesr repositories.SessionRepository,
+	rtr repositories.RefreshTokenRepository,
+	vr repositories.VerificationTokenRepository,
+	nr repositories.NotificationRepository,
+	jrr repositories.JoinRequestRepository,
+	msr repositories.MeetingSeriesRepository,
+	ss SessionService,
+	ms MeetingService,
+	ws WaitlistService,
+	cm ConnectionManager,
+	ec chan<- models.MeetingEvent,
+) AccountService {
+	return &accountService{
+		userRepo:         ur,
+		meetingRepo:      mr,
+		friendRepo:       fr,
+		saveRepo:         sr,
+		chatRepo:         cr,
+		sessionRepo:      sesr,
+		refreshTokenRepo: rtr,
+		verificationRepo: vr,
+		notificationRepo: nr,
+		joinRequestRepo:  jrr,
+		seriesRepo:       msr,
+		sessionService:   ss,
+		meetings:         ms,
+		waitlist:         ws,
+		connections:      cm,
+		eventChan:        ec,
+	}
+}
+
+// 바로 지우지 않고 유예 기간 뒤에 삭제. 그 사이에 다시 로그인해서 취소할 수 있음
+func (s *accountService) RequestDeletion(ctx context.Context, userID string, req models.DeleteAccountRequest) (*time.Time, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	policy := req.HostedMeetings
+	if policy == "" {
+		policy = models.HostPolicyTransfer
+	}
+	if policy != models.HostPolicyCancel && policy != models.HostPolicyTransfer {
+		return nil, apperr.BadRequest("hostedMeetings must be CANCEL or TRANSFER", nil)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, uID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by ID", err)
+	}
+	if user == nil {
+		return nil, apperr.NotFound("user not found", nil)
+	}
+	if user.DeletionScheduledAt != nil {
+		return nil, apperr.Conflict("account deletion already requested", nil)
+	}
+
+	scheduledAt := time.Now().AddDate(0, 0, config.AppConfig.AccountDeletionGraceDays)
+	if err := s.userRepo.ScheduleDeletion(ctx, uID, scheduledAt, policy); err != nil {
+		return nil, apperr.InternalServerError("failed to schedule account deletion", err)
+	}
+
+	// 유예 기간 동안은 로그인할 수 없어서 모임을 관리할 방장이 없어지므로 양도는 바로 함.
+	// 취소는 참여자에게 되돌릴 수 없는 일이라 탈퇴를 철회할 수 있는 유예 기간이 끝날 때까지 미룸.
+	// 여기서 넘기지 못한 모임(다른 참여자가 없거나 실패)은 삭제 때 다시 처리됨
+	if policy == models.HostPolicyTransfer {
+		s.transferHostedMeetings(ctx, uID)
+	}
+
+	// 모든 기기에서 로그아웃시키고 열려 있는 채팅 연결도 끊음
+	if err := s.sessionService.RevokeAllSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+	s.connections.DisconnectUser(userID)
+
+	return &scheduledAt, nil
+}
+
+func (s *accountService) CancelDeletion(ctx context.Context, userID string) error {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	cancelled, err := s.userRepo.CancelDeletion(ctx, uID)
+	if err != nil {
+		return apperr.InternalServerError("failed to cancel account deletion", err)
+	}
+	if !cancelled {
+		return apperr.BadRequest("account deletion is not scheduled", nil)
+	}
+
+	return nil
+}
+
+// 유예 기간이 끝난 계정을 정리함. 중간에 실패해도 다음 실행에서 이어서 처리되도록 각 단계는 멱등하게 작성
+func (s *accountService) PurgeDueAccounts(ctx context.Context) (int, error) {
+	users, err := s.userRepo.FindDueForDeletion(ctx, time.Now(), 50)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, user := range users {
+		if err := s.purgeAccount(ctx, &user); err != nil {
+			log.Printf("Failed to purge account %s: %v", user.ID.Hex(), err)
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (s *accountService) purgeAccount(ctx context.Context, user *models.User) error {
+	// 새 회차가 만들어지지 않도록 반복 모임부터 정리
+	if err := s.seriesRepo.EndByHost(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.seriesRepo.RemoveMemberFromAll(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err := s.handleMeetings(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.friendRepo.DeleteAllByUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err := s.removeSaves(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err := s.chatRepo.AnonymizeSender(ctx, user.ID, models.DeletedUserName); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.DeleteAllByUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.refreshTokenRepo.DeleteAllByUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.verificationRepo.DeleteAllByUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.notificationRepo.DeleteAllByUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.joinRequestRepo.DeleteAllByUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.waitlist.RemoveUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	return s.userRepo.Delete(ctx, user.ID)
+}
+
+// 아직 안 끝난 모임만 처리. 방장이면 정책대로 취소/양도하고, 참여자 목록에서는 빠짐
+func (s *accountService) handleMeetings(ctx context.Context, user *models.User) error {
+	activeStatuses := []string{models.MeetingStatusRecruiting, models.MeetingStatusFull}
+
+	meetings, err := s.meetingRepo.FindByParticipant(ctx, user.ID, activeStatuses)
+	if err != nil {
+		return err
+	}
+
+	for _, meeting := range meetings {
+		if meeting.HostID == user.ID {
+			transferred := false
+			if user.DeletionHostPolicy != models.HostPolicyCancel {
+				transferred, err = s.transferToOldestParticipant(ctx, &meeting, user.ID)
+				if err != nil {
+					return err
+				}
+			}
+			// 방장이 직접 취소할 때와 같이 채팅방 안내, 대기열 정리, 알림까지 거침
+			if !transferred {
+				if err := s.meetings.CancelForDeletedHost(ctx, &meeting); err != nil {
+					return err
+				}
+			}
+		}
+
+		removed, err := s.meetingRepo.RemoveParticipant(ctx, meeting.ID, user.ID)
+		if err != nil {
+			return err
+		}
+		if removed {
+			if err := s.waitlist.Promote(ctx, meeting.ID); err != nil {
+				log.Printf("Failed to promote waitlist of meeting %s: %v", meeting.ID.Hex(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *accountService) transferHostedMeetings(ctx context.Context, hostID primitive.ObjectID) {
+	activeStatuses := []string{models.MeetingStatusRecruiting, models.MeetingStatusFull}
+
+	meetings, err := s.meetingRepo.FindByParticipant(ctx, hostID, activeStatuses)
+	if err != nil {
+		log.Printf("Failed to fetch hosted meetings of %s: %v", hostID.Hex(), err)
+		return
+	}
+
+	for _, meeting := range meetings {
+		if meeting.HostID != hostID {
+			continue
+		}
+		if _, err := s.transferToOldestParticipant(ctx, &meeting, hostID); err != nil {
+			log.Printf("Failed to transfer host of meeting %s: %v", meeting.ID.Hex(), err)
+		}
+	}
+}
+
+// participant_ids는 참여 순서대로 쌓이므로 방장 다음 첫 번째가 가장 오래된 참여자
+func (s *accountService) transferToOldestParticipant(ctx context.Context, meeting *models.Meeting, hostID primitive.ObjectID) (bool, error) {
+	for _, pID := range meeting.ParticipantIDs {
+		if pID == hostID {
+			continue
+		}
+
+		transferred, err := s.meetingRepo.TransferHost(ctx, meeting.ID, hostID, pID)
+		if err != nil || !transferred {
+			return transferred, err
+		}
+
+		s.eventChan <- models.MeetingEvent{
+			Type:      models.EventTransferHost,
+			MeetingID: meeting.ID.Hex(),
+			UserID:    pID.Hex(),
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// 저장 기록을 하나씩 지우고, 실제로 지워진 경우에만 save_count를 줄임
+func (s *accountService) removeSaves(ctx context.Context, userID primitive.ObjectID) error {
+	saves, err := s.saveRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, save := range saves {
+		deleted, err := s.saveRepo.Delete(ctx, userID, save.MeetingID)
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			continue
+		}
+		if err := s.meetingRepo.DecrementSaveCount(ctx, save.MeetingID); err != nil {
+			log.Printf("Failed to decrement save count of meeting %s: %v", save.MeetingID.Hex(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// api/services/account_service_test.go
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memMeetingRepo struct {
+	repositories.MeetingRepository
+	meeting *models.Meeting
+	created []models.Meeting
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memMeetingRepo) FindByParticipant(ctx context.Context, userID primitive.ObjectID, statuses []string) ([]models.Meeting, error) {
+	if r.meeting == nil || !containsID(r.meeting.ParticipantIDs, userID) || !containsString(statuses, r.meeting.Status) {
+		return nil, nil
+	}
+	return []models.Meeting{*r.meeting}, nil
+}
+
+func (r *memMeetingRepo) TransferHost(ctx context.Context, meetingID, fromID, toID primitive.ObjectID) (bool, error) {
+	if r.meeting.HostID != fromID || !containsID(r.meeting.ParticipantIDs, toID) {
+		return false, nil
+	}
+	r.meeting.HostID = toID
+	return true, nil
+}
+
+func TestTransferHostedMeetings(t *testing.T) {
+	hostID := primitive.NewObjectID()
+	oldest := primitive.NewObjectID()
+	newest := primitive.NewObjectID()
+
+	tests := []struct {
+		name         string
+		status       string
+		participants []primitive.ObjectID
+		wantHost     primitive.ObjectID
+	}{
+		{name: "oldest participant", status: models.MeetingStatusRecruiting, participants: []primitive.ObjectID{hostID, oldest, newest}, wantHost: oldest},
+		{name: "full meeting", status: models.MeetingStatusFull, participants: []primitive.ObjectID{hostID, oldest}, wantHost: oldest},
+		{name: "host alone waits for purge", status: models.MeetingStatusRecruiting, participants: []primitive.ObjectID{hostID}, wantHost: hostID},
+		{name: "finished meeting untouched", status: models.MeetingStatusFinished, participants: []primitive.ObjectID{hostID, oldest}, wantHost: hostID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meeting := &models.Meeting{
+				ID:             primitive.NewObjectID(),
+				HostID:         hostID,
+				ParticipantIDs: tt.participants,
+				Status:         tt.status,
+			}
+			events := make(chan models.MeetingEvent, 1)
+			s := &accountService{meetingRepo: &memMeetingRepo{meeting: meeting}, eventChan: events}
+
+			s.transferHostedMeetings(context.Background(), hostID)
+
+			if meeting.HostID != tt.wantHost {
+				t.Errorf("host = %s, want %s", meeting.HostID.Hex(), tt.wantHost.Hex())
+			}
+			if transferred := tt.wantHost != hostID; transferred != (len(events) == 1) {
+				t.Errorf("transfer events = %d", len(events))
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// api/services/admin_service.go
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 운영자용 기능. 라우트에서 역할을 먼저 거르고, 대상 유저와의 권한 비교는 여기서 함
+type AdminService interface {
+	GetUser(ctx context.Context, targetID string) (*models.User, error)
+	ChangeRole(ctx context.Context, actorID, targetID, role string) error
+	RevokeUserSessions(ctx context.Context, actorID, actorRole, targetID string) error
+	ListAuditLogs(ctx context.Context, subject string, limit int) ([]models.AuditLog, error)
+	BootstrapAdmins(ctx context.Context, usernames []string)
+}
+
+type adminService struct {
+	userRepo       repositories.UserRepository
+	auditLogRepo   repositories.AuditLogRepository
+	sessionService SessionService
+	revocations    RevocationService
+}
+
+func NewAdminService(ur repositories.UserRepository, alr repositories.AuditLogRepository, ss SessionService, rs RevocationService) AdminService {
+	return &adminService{
+		userRepo:       ur,
+		auditLogRepo:   alr,
+		sessionService: ss,
+		revocations:    rs,
+	}
+}
+
+func (s *adminService) GetUser(ctx context.Context, targetID string) (*models.User, error) {
+	tID, err := primitive.ObjectIDFromHex(targetID)
+	if err != nil {
+		return nil, apperr.BadRequest("invalid user ID format", err)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, tID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by id", err)
+	}
+	if user == nil {
+		return nil, apperr.NotFound("user not found", nil)
+	}
+	return user, nil
+}
+
+// 기존 액세스 토큰을 전부 폐기해서 다음 리프레시 때 새 역할이 담긴 토큰을 받게 함.
+// 리프레시 토큰과 세션은 그대로라 다시 로그인할 필요는 없음
+func (s *adminService) ChangeRole(ctx context.Context, actorID, targetID, role string) error {
+	if !models.IsValidRole(role) {
+		return apperr.BadRequest("invalid role", nil)
+	}
+	if actorID == targetID {
+		return apperr.BadRequest("cannot change your own role", nil)
+	}
+
+	aID, err := primitive.ObjectIDFromHex(actorID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	target, err := s.GetUser(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	previous := target.EffectiveRole()
+	if previous == role {
+		return nil
+	}
+
+	updated, err := s.userRepo.UpdateRole(ctx, target.ID, role)
+	if err != nil {
+		return apperr.InternalServerError("failed to update role", err)
+	}
+	if !updated {
+		return apperr.NotFound("user not found", nil)
+	}
+
+	if err := s.revocations.RevokeUserTokens(ctx, target.ID.Hex()); err != nil {
+		return err
+	}
+
+	s.writeAuditLog(ctx, &models.AuditLog{
+		Action:  models.AuditActionRoleChanged,
+		UserID:  &target.ID,
+		ActorID: &aID,
+		Subject: target.Username,
+		Detail:  fmt.Sprintf("%s -> %s", previous, role),
+	})
+	return nil
+}
+
+// 자기보다 높거나 같은 역할의 계정은 건드릴 수 없음
+func (s *adminService) RevokeUserSessions(ctx context.Context, actorID, actorRole, targetID string) error {
+	aID, err := primitive.ObjectIDFromHex(actorID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	target, err := s.GetUser(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	if actorID != targetID && models.RoleAtLeast(target.EffectiveRole(), actorRole) {
+		return apperr.Forbidden("cannot act on a user with an equal or higher role", nil)
+	}
+
+	if err := s.sessionService.RevokeAllSessions(ctx, target.ID.Hex()); err != nil {
+		return err
+	}
+
+	s.writeAuditLog(ctx, &models.AuditLog{
+		Action:  models.AuditActionSessionsRevoked,
+		UserID:  &target.ID,
+		ActorID: &aID,
+		Subject: target.Username,
+	})
+	return nil
+}
+
+func (s *adminService) ListAuditLogs(ctx context.Context, subject string, limit int) ([]models.AuditLog, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	logs, err := s.auditLogRepo.List(ctx, subject, int64(limit))
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch audit logs", err)
+	}
+	return logs, nil
+}
+
+// 첫 관리자를 만들 방법이 없으므로 설정에 적힌 계정을 서버 시작 시 관리자로 올림
+func (s *adminService) BootstrapAdmins(ctx context.Context, usernames []string) {
+	for _, username := range usernames {
+		user, err := s.userRepo.FindByUsername(ctx, username)
+		if err != nil {
+			log.Printf("Failed to look up bootstrap admin %s: %v", username, err)
+			continue
+		}
+		if user == nil {
+			log.Printf("Bootstrap admin %s does not exist yet", username)
+			continue
+		}
+		if user.EffectiveRole() == models.RoleAdmin {
+			continue
+		}
+
+		if _, err := s.userRepo.UpdateRole(ctx, user.ID, models.RoleAdmin); err != nil {
+			log.Printf("Failed to promote bootstrap admin %s: %v", username, err)
+			continue
+		}
+		if err := s.revocations.RevokeUserTokens(ctx, user.ID.Hex()); err != nil {
+			log.Printf("Failed to revoke tokens of bootstrap admin %s: %v", username, err)
+		}
+
+		s.writeAuditLog(ctx, &models.AuditLog{
+			Action:  models.AuditActionRoleChanged,
+			UserID:  &user.ID,
+			Subject: user.Username,
+			Detail:  fmt.Sprintf("%s -> %s (bootstrap)", user.EffectiveRole(), models.RoleAdmin),
+		})
+		log.Printf("Promoted %s to admin", username)
+	}
+}
+
+// 감사 기록 실패로 이미 끝난 조치를 되돌리지는 않음
+func (s *adminService) writeAuditLog(ctx context.Context, entry *models.AuditLog) {
+	entry.CreatedAt = time.Now()
+	if err := s.auditLogRepo.Create(ctx, entry); err != nil {
+		log.Printf("Failed to write audit log (%s %s): %v", entry.Action, entry.Subject, err)
+	}
+}
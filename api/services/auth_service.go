@@ -4,15 +4,11 @@ package services
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"log"
 	"net/http"
-	"strconv"
+	"strings"
 	"time"
 
-	"cloud.google.com/go/auth/credentials/idtoken"
-	"github.com/MicahParks/keyfunc/v3"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/seojoonrp/bbiyong-backend/api/repositories"
 	"github.com/seojoonrp/bbiyong-backend/apperr"
 	"github.com/seojoonrp/bbiyong-backend/config"
@@ -20,38 +16,70 @@ import (
 	"github.com/seojoonrp/bbiyong-backend/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService interface {
 	Register(ctx context.Context, req models.RegisterRequest) error
-	Login(ctx context.Context, req models.LoginRequest) (string, *models.User, error)
-	LoginWithGoogle(ctx context.Context, idToken string) (bool, string, *models.User, error)
-	LoginWithKakao(ctx context.Context, accessToken string) (bool, string, *models.User, error)
-	LoginWithApple(ctx context.Context, identityToken string) (bool, string, *models.User, error)
+	Login(ctx context.Context, req models.LoginRequest, client models.ClientInfo) (*models.TokenPair, *models.User, string, error)
+	VerifyTwoFactor(ctx context.Context, challengeToken, code string, client models.ClientInfo) (*models.TokenPair, *models.User, error)
+	LoginWithGoogle(ctx context.Context, idToken string, client models.ClientInfo) (bool, *models.TokenPair, *models.User, error)
+	LoginWithKakao(ctx context.Context, accessToken string, client models.ClientInfo) (bool, *models.TokenPair, *models.User, error)
+	LoginWithApple(ctx context.Context, identityToken string, client models.ClientInfo) (bool, *models.TokenPair, *models.User, error)
+	Refresh(ctx context.Context, refreshToken string, client models.ClientInfo) (*models.TokenPair, error)
+	Logout(ctx context.Context, userID, sessionID, jti string, expiresAt time.Time, refreshToken string) error
+	LogoutAll(ctx context.Context, userID string) error
+	LinkSocialIdentity(ctx context.Context, userID, provider, token string) error
+	LinkLocalCredentials(ctx context.Context, userID string, req models.LinkLocalRequest) error
+	UnlinkIdentity(ctx context.Context, userID, provider string) error
 	IsUsernameAvailable(ctx context.Context, username string) (bool, error)
 	CompleteProfile(ctx context.Context, userID string, req models.SetProfileRequest) error
 }
 
 type authService struct {
-	userRepo repositories.UserRepository
+	userRepo          repositories.UserRepository
+	refreshTokenRepo  repositories.RefreshTokenRepository
+	tokenService      TokenService
+	loginThrottle     LoginThrottleService
+	verification      VerificationService
+	twoFactor         TwoFactorService
+	sessionService    SessionService
+	revocations       RevocationService
+	identityProviders map[string]IdentityProvider
 }
 
-func NewAuthService(repo repositories.UserRepository) AuthService {
-	return &authService{userRepo: repo}
+func NewAuthService(ur repositories.UserRepository, rtr repositories.RefreshTokenRepository, ts TokenService, lts LoginThrottleService, vs VerificationService, tfs TwoFactorService, ss SessionService, rs RevocationService, idps []IdentityProvider) AuthService {
+	providers := make(map[string]IdentityProvider, len(idps))
+	for _, idp := range idps {
+		providers[idp.Provider()] = idp
+	}
+
+	return &authService{
+		userRepo:          ur,
+		refreshTokenRepo:  rtr,
+		tokenService:      ts,
+		loginThrottle:     lts,
+		verification:      vs,
+		twoFactor:         tfs,
+		sessionService:    ss,
+		revocations:       rs,
+		identityProviders: providers,
+	}
 }
 
 func (s *authService) Register(ctx context.Context, req models.RegisterRequest) error {
-	if len(req.Username) < 3 || len(req.Username) > 15 {
-		return apperr.BadRequest("username must be between 3 and 15 characters", nil)
+	if err := s.checkNewUsername(ctx, req.Username); err != nil {
+		return err
 	}
 
-	exists, err := s.userRepo.FindByUsername(ctx, req.Username)
+	email := NormalizeEmail(req.Email)
+	existing, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		return apperr.InternalServerError("failed to fetch user by username", err)
+		return apperr.InternalServerError("failed to fetch user by email", err)
 	}
-	if exists != nil {
-		return apperr.BadRequest("username already exists", nil)
+	if existing != nil {
+		return apperr.Conflict("email already in use", nil)
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), 10)
@@ -62,6 +90,7 @@ func (s *authService) Register(ctx context.Context, req models.RegisterRequest)
 	user := models.User{
 		Username:     req.Username,
 		Password:     string(hashedPassword),
+		Email:        email,
 		Nickname:     "",
 		ProfileURI:   "",
 		Age:          -1,
@@ -70,45 +99,271 @@ func (s *authService) Register(ctx context.Context, req models.RegisterRequest)
 		Location:     models.Location{},
 		RegionName:   "",
 		Provider:     models.ProviderLocal,
+		Identities:   []models.Identity{newIdentity(models.ProviderLocal, req.Username, "")},
 		IsProfileSet: false,
+		Role:         models.RoleUser,
 		CreatedAt:    time.Now(),
 	}
 
 	if err := s.userRepo.Create(ctx, &user); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return apperr.Conflict("username or email already in use", err)
+		}
 		return apperr.InternalServerError("failed to create user", err)
 	}
 
+	// 가입은 이미 끝났으므로 메일 발송에 실패해도 나중에 다시 요청할 수 있음
+	if err := s.verification.SendEmailVerification(ctx, user.ID.Hex()); err != nil {
+		log.Printf("Failed to send verification email to %s: %v", user.ID.Hex(), err)
+	}
+
 	return nil
 }
 
-func (s *authService) Login(ctx context.Context, req models.LoginRequest) (string, *models.User, error) {
-	user, usernameErr := s.userRepo.FindByUsername(ctx, req.Username)
-	passwordErr := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
-	if user == nil || usernameErr != nil || passwordErr != nil {
-		return "", nil, apperr.Unauthorized("invalid username or password", nil)
+func (s *authService) checkNewUsername(ctx context.Context, username string) error {
+	if len(username) < 3 || len(username) > 15 {
+		return apperr.BadRequest("username must be between 3 and 15 characters", nil)
 	}
 
-	signedToken, err := utils.GenerateToken(user.ID.Hex())
+	exists, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
-		return "", nil, apperr.InternalServerError("failed to generate token", err)
+		return apperr.InternalServerError("failed to fetch user by username", err)
+	}
+	if exists != nil {
+		return apperr.BadRequest("username already exists", nil)
 	}
 
-	return signedToken, user, nil
+	return nil
 }
 
-func (s *authService) loginWithSocial(ctx context.Context, provider string, socialID string, email string) (bool, string, *models.User, error) {
-	targetUsername := utils.GenerateHashUsername(provider, socialID)
+// 2단계 인증을 켠 계정은 토큰 대신 챌린지 토큰을 돌려줌. VerifyTwoFactor에서 코드와 함께 교환
+func (s *authService) Login(ctx context.Context, req models.LoginRequest, client models.ClientInfo) (*models.TokenPair, *models.User, string, error) {
+	if err := s.loginThrottle.Check(ctx, req.Username, client.IP); err != nil {
+		return nil, nil, "", err
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, nil, "", apperr.InternalServerError("failed to fetch user by username", err)
+	}
+	// 로컬 로그인을 연결 해제한 계정은 비밀번호가 없음
+	if user == nil || user.Password == "" {
+		s.loginThrottle.RecordFailure(ctx, req.Username, client.IP, nil)
+		return nil, nil, "", apperr.Unauthorized("invalid username or password", nil)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		s.loginThrottle.RecordFailure(ctx, req.Username, client.IP, &user.ID)
+		return nil, nil, "", apperr.Unauthorized("invalid username or password", nil)
+	}
+
+	// 코드 확인까지 끝나야 실패 카운터를 초기화함
+	if user.TwoFactor != nil && user.TwoFactor.Enabled {
+		challenge, err := s.tokenService.IssueChallengeToken(user.ID.Hex(), user.TokenVersion)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		return nil, nil, challenge, nil
+	}
+	s.loginThrottle.RecordSuccess(ctx, req.Username)
+
+	tokens, err := s.startSession(ctx, user, models.ProviderLocal, client)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return tokens, user, "", nil
+}
+
+// 코드 실패도 비밀번호 실패와 같은 카운터로 세서 챌린지 토큰 하나로 대입하지 못하게 함
+func (s *authService) VerifyTwoFactor(ctx context.Context, challengeToken, code string, client models.ClientInfo) (*models.TokenPair, *models.User, error) {
+	claims, err := s.tokenService.ParseChallengeToken(challengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.revocations.IsRevoked(claims.JTI, claims.UserID, "", claims.TokenVersion) {
+		return nil, nil, apperr.Unauthorized("challenge token already used", nil)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, nil, apperr.Unauthorized("invalid user ID in token", err)
+	}
+	user, err := s.userRepo.FindByID(ctx, uID)
+	if err != nil {
+		return nil, nil, apperr.InternalServerError("failed to fetch user by id", err)
+	}
+	if user == nil {
+		return nil, nil, apperr.Unauthorized("user not found", nil)
+	}
+
+	if err := s.loginThrottle.Check(ctx, user.Username, client.IP); err != nil {
+		return nil, nil, err
+	}
+
+	ok, err := s.twoFactor.VerifyCode(ctx, user, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		s.loginThrottle.RecordFailure(ctx, user.Username, client.IP, &user.ID)
+		return nil, nil, apperr.Unauthorized("invalid two-factor code", nil)
+	}
+
+	if err := s.revocations.RevokeToken(ctx, claims.JTI, claims.ExpiresAt); err != nil {
+		return nil, nil, err
+	}
+	s.loginThrottle.RecordSuccess(ctx, user.Username)
+
+	tokens, err := s.startSession(ctx, user, models.ProviderLocal, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, user, nil
+}
+
+// 새 기기 세션을 만들고 그 세션에 묶인 첫 토큰 쌍을 발급
+func (s *authService) startSession(ctx context.Context, user *models.User, provider string, client models.ClientInfo) (*models.TokenPair, error) {
+	session, err := s.sessionService.StartSession(ctx, user.ID, provider, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, session.ID, primitive.NewObjectID())
+}
+
+// 액세스 토큰과 리프레시 토큰을 함께 발급. 리프레시 토큰은 해시만 저장함
+func (s *authService) issueTokens(ctx context.Context, user *models.User, sessionID, familyID primitive.ObjectID) (*models.TokenPair, error) {
+	accessToken, err := s.tokenService.IssueAccessToken(user.ID.Hex(), sessionID.Hex(), user.EffectiveRole(), user.TokenVersion, user.IsProfileSet, user.DeletionScheduledAt != nil)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to generate refresh token", err)
+	}
+
+	now := time.Now()
+	err = s.refreshTokenRepo.Create(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		SessionID: sessionID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: now.Add(config.AppConfig.RefreshTokenTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to store refresh token", err)
+	}
+
+	return &models.TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func (s *authService) Refresh(ctx context.Context, refreshToken string, client models.ClientInfo) (*models.TokenPair, error) {
+	stored, err := s.refreshTokenRepo.FindByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch refresh token", err)
+	}
+	if stored == nil {
+		return nil, apperr.Unauthorized("invalid refresh token", nil)
+	}
+
+	// 이미 쓰였거나 폐기된 토큰이 다시 들어왔다 -> 탈취 가능성이 있으니 패밀리 전체 폐기
+	if stored.UsedAt != nil || stored.RevokedAt != nil {
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, apperr.InternalServerError("failed to revoke token family", err)
+		}
+		return nil, apperr.Unauthorized("refresh token reuse detected", nil)
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, apperr.Unauthorized("refresh token expired", nil)
+	}
+
+	marked, err := s.refreshTokenRepo.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to rotate refresh token", err)
+	}
+	if !marked {
+		// 동시에 들어온 다른 요청이 먼저 사용함 -> 이것도 재사용으로 취급
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, apperr.InternalServerError("failed to revoke token family", err)
+		}
+		return nil, apperr.Unauthorized("refresh token reuse detected", nil)
+	}
+
+	// 세션 도입 전에 발급된 리프레시 토큰은 세션 없이 그대로 회전
+	if !stored.SessionID.IsZero() {
+		if err := s.sessionService.TouchSession(ctx, stored.SessionID, client); err != nil {
+			return nil, err
+		}
+	}
+
+	// 역할이 바뀌었을 수 있으므로 매번 DB에서 다시 읽어서 토큰에 반영
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by id", err)
+	}
+	if user == nil {
+		return nil, apperr.Unauthorized("user not found", nil)
+	}
+
+	return s.issueTokens(ctx, user, stored.SessionID, stored.FamilyID)
+}
+
+func (s *authService) Logout(ctx context.Context, userID, sessionID, jti string, expiresAt time.Time, refreshToken string) error {
+	if err := s.revocations.RevokeToken(ctx, jti, expiresAt); err != nil {
+		return err
+	}
+
+	// 현재 기기 세션을 끊으면 그 세션의 리프레시 토큰도 같이 폐기됨
+	if sessionID != "" {
+		err := s.sessionService.RevokeSession(ctx, userID, sessionID)
+		if appErr, ok := err.(*apperr.AppError); ok && appErr.StatusCode == http.StatusNotFound {
+			err = nil // 이미 다른 기기에서 끊은 세션
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := s.refreshTokenRepo.FindByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch refresh token", err)
+	}
+	// 남의 리프레시 토큰으로는 폐기할 수 없음
+	if stored == nil || stored.UserID.Hex() != userID {
+		return nil
+	}
+
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return apperr.InternalServerError("failed to revoke refresh token", err)
+	}
+
+	return nil
+}
+
+func (s *authService) LogoutAll(ctx context.Context, userID string) error {
+	return s.sessionService.RevokeAllSessions(ctx, userID)
+}
+
+func (s *authService) loginWithSocial(ctx context.Context, provider string, socialID string, email string, client models.ClientInfo) (bool, *models.TokenPair, *models.User, error) {
 	isNew := false
 
-	user, err := s.userRepo.FindByUsername(ctx, targetUsername)
+	user, err := s.findBySocialIdentity(ctx, provider, socialID)
 	if err != nil {
-		return false, "", nil, apperr.InternalServerError("failed to fetch user by username", err)
+		return false, nil, nil, err
 	}
 
 	if user == nil {
 		isNew = true
 		user = &models.User{
-			Username:     targetUsername,
+			Username:     utils.GenerateHashUsername(provider, socialID),
 			Nickname:     "",
 			ProfileURI:   "",
 			Age:          -1,
@@ -118,7 +373,9 @@ func (s *authService) loginWithSocial(ctx context.Context, provider string, soci
 			RegionName:   "",
 			Provider:     provider,
 			SocialID:     socialID,
+			Identities:   []models.Identity{newIdentity(provider, socialID, email)},
 			IsProfileSet: false,
+			Role:         models.RoleUser,
 			CreatedAt:    time.Now(),
 		}
 		if email != "" {
@@ -126,104 +383,234 @@ func (s *authService) loginWithSocial(ctx context.Context, provider string, soci
 		}
 
 		if err := s.userRepo.Create(ctx, user); err != nil {
-			return false, "", nil, apperr.InternalServerError("failed to create user", err)
+			return false, nil, nil, apperr.InternalServerError("failed to create user", err)
 		}
 	}
 
-	signedToken, err := utils.GenerateToken(user.ID.Hex())
+	tokens, err := s.startSession(ctx, user, provider, client)
 	if err != nil {
-		return false, "", nil, apperr.InternalServerError("failed to generate token", err)
+		return false, nil, nil, err
 	}
 
-	return isNew, signedToken, user, nil
+	return isNew, tokens, user, nil
 }
 
-func (s *authService) LoginWithGoogle(ctx context.Context, idToken string) (bool, string, *models.User, error) {
-	webClientID := config.AppConfig.GoogleWebClientID
+// 연결된 로그인 수단으로 유저를 찾음.
+// identities 도입 전 가입자는 해시 username으로 찾은 뒤 연결 정보를 채워줌
+func (s *authService) findBySocialIdentity(ctx context.Context, provider, socialID string) (*models.User, error) {
+	user, err := s.userRepo.FindByIdentity(ctx, provider, socialID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by identity", err)
+	}
+	if user != nil {
+		return user, nil
+	}
 
-	payload, err := idtoken.Validate(context.Background(), idToken, webClientID)
+	user, err = s.userRepo.FindByUsername(ctx, utils.GenerateHashUsername(provider, socialID))
 	if err != nil {
-		return false, "", nil, apperr.Unauthorized("invalid Google ID token", err)
+		return nil, apperr.InternalServerError("failed to fetch user by username", err)
+	}
+	// identities가 이미 있는데 여기서 찾혔다면 이 수단은 연결 해제된 것
+	if user == nil || len(user.Identities) > 0 {
+		return nil, nil
+	}
+
+	if err := s.ensureIdentities(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *authService) ensureIdentities(ctx context.Context, user *models.User) error {
+	if len(user.Identities) > 0 {
+		return nil
 	}
 
-	socialID := payload.Subject
-	email, _ := payload.Claims["email"].(string)
+	if user.Provider == models.ProviderLocal {
+		user.Identities = []models.Identity{newIdentity(models.ProviderLocal, user.Username, "")}
+	} else {
+		user.Identities = []models.Identity{newIdentity(user.Provider, user.SocialID, user.SocialEmail)}
+	}
 
-	return s.loginWithSocial(ctx, models.ProviderGoogle, socialID, email)
+	if err := s.userRepo.BackfillIdentities(ctx, user.ID, user.Identities); err != nil {
+		return apperr.InternalServerError("failed to backfill identities", err)
+	}
+	return nil
 }
 
-func (s *authService) LoginWithKakao(ctx context.Context, accessToken string) (bool, string, *models.User, error) {
-	client := &http.Client{}
-	req, _ := http.NewRequest("GET", "https://kapi.kakao.com/v2/user/me", nil)
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+func newIdentity(provider, socialID, email string) models.Identity {
+	return models.Identity{
+		Key:      models.IdentityKey(provider, socialID),
+		Provider: provider,
+		SocialID: socialID,
+		Email:    email,
+		LinkedAt: time.Now(),
+	}
+}
 
-	resp, err := client.Do(req)
+func (s *authService) LoginWithGoogle(ctx context.Context, idToken string, client models.ClientInfo) (bool, *models.TokenPair, *models.User, error) {
+	return s.loginWithProvider(ctx, models.ProviderGoogle, idToken, client)
+}
+
+func (s *authService) LoginWithKakao(ctx context.Context, accessToken string, client models.ClientInfo) (bool, *models.TokenPair, *models.User, error) {
+	return s.loginWithProvider(ctx, models.ProviderKakao, accessToken, client)
+}
+
+func (s *authService) LoginWithApple(ctx context.Context, identityToken string, client models.ClientInfo) (bool, *models.TokenPair, *models.User, error) {
+	return s.loginWithProvider(ctx, models.ProviderApple, identityToken, client)
+}
+
+func (s *authService) loginWithProvider(ctx context.Context, provider, token string, client models.ClientInfo) (bool, *models.TokenPair, *models.User, error) {
+	identity, err := s.verifySocialToken(ctx, provider, token)
 	if err != nil {
-		return false, "", nil, apperr.ServiceUnavailable("kakao api server unreachable", err)
+		return false, nil, nil, err
 	}
-	if resp.StatusCode == http.StatusUnauthorized {
-		return false, "", nil, apperr.Unauthorized("expired or invalid kakao token", nil)
-	} else if resp.StatusCode != http.StatusOK {
-		return false, "", nil, apperr.InternalServerError("kakao api returned error status", fmt.Errorf("status: %d", resp.StatusCode))
+
+	return s.loginWithSocial(ctx, provider, identity.SocialID, identity.Email, client)
+}
+
+func (s *authService) verifySocialToken(ctx context.Context, provider, token string) (*models.VerifiedIdentity, error) {
+	idp, ok := s.identityProviders[provider]
+	if !ok {
+		return nil, apperr.BadRequest("unsupported provider", nil)
 	}
-	defer resp.Body.Close()
 
-	var kakaoRes struct {
-		ID           int64 `json:"id"`
-		KakaoAccount struct {
-			Email   string `json:"email"`
-			Profile struct {
-				Nickname string `json:"nickname"`
-			} `json:"profile"`
-		} `json:"kakao_account"`
+	identity, err := idp.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if identity.SocialID == "" {
+		return nil, apperr.Unauthorized("social token has no subject", nil)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&kakaoRes); err != nil {
-		return false, "", nil, apperr.InternalServerError("failed to decode Kakao user info", err)
+
+	return identity, nil
+}
+
+func (s *authService) getUserForLinking(ctx context.Context, userID string) (*models.User, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
 	}
 
-	socialID := strconv.FormatInt(kakaoRes.ID, 10)
-	email := kakaoRes.KakaoAccount.Email
+	user, err := s.userRepo.FindByID(ctx, uID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by ID", err)
+	}
+	if user == nil {
+		return nil, apperr.NotFound("user not found", nil)
+	}
 
-	return s.loginWithSocial(ctx, models.ProviderKakao, socialID, email)
+	if err := s.ensureIdentities(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-func (s *authService) verifyAppleToken(identityToken string, clientID string) (jwt.MapClaims, error) {
-	appleJWKSURL := "https://appleid.apple.com/auth/keys"
+func (s *authService) LinkSocialIdentity(ctx context.Context, userID, provider, token string) error {
+	user, err := s.getUserForLinking(ctx, userID)
+	if err != nil {
+		return err
+	}
 
-	k, err := keyfunc.NewDefault([]string{appleJWKSURL})
+	identity, err := s.verifySocialToken(ctx, provider, token)
 	if err != nil {
-		return nil, apperr.InternalServerError("failed to create keyfunc", err)
+		return err
 	}
 
-	token, err := jwt.Parse(identityToken, k.Keyfunc)
+	owner, err := s.findBySocialIdentity(ctx, provider, identity.SocialID)
 	if err != nil {
-		return nil, apperr.InternalServerError("invalid token", err)
+		return err
+	}
+	if owner != nil && owner.ID != user.ID {
+		return apperr.Conflict("this social account is already linked to another user", nil)
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		if claims["iss"] != "https://appleid.apple.com" {
-			return nil, apperr.Unauthorized("invalid issuer", nil)
+	added, err := s.userRepo.AddIdentity(ctx, user.ID, newIdentity(provider, identity.SocialID, identity.Email), nil)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return apperr.Conflict("this social account is already linked to another user", err)
 		}
-		if claims["aud"] != clientID {
-			return nil, apperr.Unauthorized("invalid audience", nil)
+		return apperr.InternalServerError("failed to link identity", err)
+	}
+	if !added {
+		return apperr.Conflict("a login method for this provider is already linked", nil)
+	}
+
+	return nil
+}
+
+// 소셜로 가입한 유저가 아이디/비밀번호 로그인을 추가하는 경우
+func (s *authService) LinkLocalCredentials(ctx context.Context, userID string, req models.LinkLocalRequest) error {
+	user, err := s.getUserForLinking(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkNewUsername(ctx, req.Username); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), 10)
+	if err != nil {
+		return apperr.InternalServerError("failed to hash password", err)
+	}
+
+	added, err := s.userRepo.AddIdentity(
+		ctx,
+		user.ID,
+		newIdentity(models.ProviderLocal, req.Username, ""),
+		bson.M{"username": req.Username, "password": string(hashedPassword)},
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return apperr.BadRequest("username already exists", err)
 		}
-		return claims, nil
+		return apperr.InternalServerError("failed to link local credentials", err)
+	}
+	if !added {
+		return apperr.Conflict("local credentials are already linked", nil)
 	}
 
-	return nil, apperr.Unauthorized("invalid token claims", nil)
+	return nil
 }
 
-func (s *authService) LoginWithApple(ctx context.Context, identityToken string) (bool, string, *models.User, error) {
-	clientID := config.AppConfig.AppleBundleID
-	claims, err := s.verifyAppleToken(identityToken, clientID)
+func (s *authService) UnlinkIdentity(ctx context.Context, userID, provider string) error {
+	user, err := s.getUserForLinking(ctx, userID)
 	if err != nil {
-		return false, "", nil, err
+		return err
 	}
 
-	socialID, _ := claims["sub"].(string)
-	email, _ := claims["email"].(string)
+	linked := false
+	for _, identity := range user.Identities {
+		if identity.Provider == provider {
+			linked = true
+			break
+		}
+	}
+	if !linked {
+		return apperr.NotFound("login method not linked", nil)
+	}
+	if len(user.Identities) <= 1 {
+		return apperr.Conflict("cannot unlink the last remaining login method", nil)
+	}
+
+	// 로컬 로그인을 끊으면 비밀번호를 지우고 username은 다시 내부용 값으로 돌려놓음
+	var updates, unsets bson.M
+	if provider == models.ProviderLocal {
+		updates = bson.M{"username": utils.GenerateHashUsername(models.ProviderLocal, user.ID.Hex())}
+		unsets = bson.M{"password": ""}
+	}
 
-	return s.loginWithSocial(ctx, models.ProviderApple, socialID, email)
+	removed, err := s.userRepo.RemoveIdentity(ctx, user.ID, provider, updates, unsets)
+	if err != nil {
+		return apperr.InternalServerError("failed to unlink identity", err)
+	}
+	if !removed {
+		// 그 사이 다른 요청으로 수단이 하나만 남게 된 경우
+		return apperr.Conflict("cannot unlink the last remaining login method", nil)
+	}
+
+	return nil
 }
 
 func (s *authService) IsUsernameAvailable(ctx context.Context, username string) (bool, error) {
@@ -240,13 +627,17 @@ func (s *authService) CompleteProfile(ctx context.Context, userID string, req mo
 		return apperr.InternalServerError("invalid user ID in token", err)
 	}
 
+	if err := validateProfile(req); err != nil {
+		return err
+	}
+
 	updates := bson.M{
-		"nickname":       req.Nickname,
+		"nickname":       strings.TrimSpace(req.Nickname),
 		"profile_uri":    req.ProfileURI,
 		"age":            req.Age,
 		"gender":         req.Gender,
 		"location":       req.Location,
-		"region_name":    req.RegionName,
+		"region_name":    strings.TrimSpace(req.RegionName),
 		"is_profile_set": true,
 	}
 
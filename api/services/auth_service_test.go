@@ -0,0 +1,281 @@
+// api/services/auth_service_test.go
+
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 소셜 로그인 흐름에서 쓰는 메서드만 메모리로 구현. 나머지를 부르면 nil 인터페이스라 바로 패닉남
+type memUserRepo struct {
+	repositories.UserRepository
+	users map[primitive.ObjectID]*models.User
+}
+
+func newMemUserRepo(users ...*models.User) *memUserRepo {
+	r := &memUserRepo{users: make(map[primitive.ObjectID]*models.User)}
+	for _, u := range users {
+		r.users[u.ID] = u
+	}
+	return r
+}
+
+func (r *memUserRepo) Create(ctx context.Context, user *models.User) error {
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *memUserRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+	return r.users[id], nil
+}
+
+func (r *memUserRepo) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	for _, u := range r.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memUserRepo) FindByIdentity(ctx context.Context, provider, socialID string) (*models.User, error) {
+	key := models.IdentityKey(provider, socialID)
+	for _, u := range r.users {
+		for _, identity := range u.Identities {
+			if identity.Key == key {
+				return u, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (r *memUserRepo) BackfillIdentities(ctx context.Context, id primitive.ObjectID, identities []models.Identity) error {
+	r.users[id].Identities = identities
+	return nil
+}
+
+func (r *memUserRepo) AddIdentity(ctx context.Context, id primitive.ObjectID, identity models.Identity, updates bson.M) (bool, error) {
+	user := r.users[id]
+	for _, existing := range user.Identities {
+		if existing.Provider == identity.Provider {
+			return false, nil
+		}
+	}
+	user.Identities = append(user.Identities, identity)
+	return true, nil
+}
+
+type memRefreshTokenRepo struct {
+	repositories.RefreshTokenRepository
+	tokens []*models.RefreshToken
+}
+
+func (r *memRefreshTokenRepo) Create(ctx context.Context, token *models.RefreshToken) error {
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+type stubTokenService struct {
+	TokenService
+}
+
+func (stubTokenService) IssueAccessToken(userID, sessionID, role string, tokenVersion int, profileSet, deletionPending bool) (string, error) {
+	return "access:" + userID, nil
+}
+
+type stubSessionService struct {
+	SessionService
+}
+
+func (stubSessionService) StartSession(ctx context.Context, userID primitive.ObjectID, provider string, client models.ClientInfo) (*models.Session, error) {
+	return &models.Session{ID: primitive.NewObjectID(), UserID: userID, Provider: provider}, nil
+}
+
+func newTestAuthService(users *memUserRepo, idps ...IdentityProvider) *authService {
+	return NewAuthService(users, &memRefreshTokenRepo{}, stubTokenService{}, nil, nil, nil, stubSessionService{}, nil, idps).(*authService)
+}
+
+func statusOf(err error) int {
+	var appErr *apperr.AppError
+	if errors.As(err, &appErr) {
+		return appErr.StatusCode
+	}
+	return 0
+}
+
+func TestLoginWithSocial(t *testing.T) {
+	existing := &models.User{
+		ID:         primitive.NewObjectID(),
+		Username:   "existing",
+		Provider:   models.ProviderGoogle,
+		Identities: []models.Identity{newIdentity(models.ProviderGoogle, "g-existing", "old@example.com")},
+	}
+
+	tests := []struct {
+		name       string
+		identities map[string]models.VerifiedIdentity
+		token      string
+		wantNew    bool
+		wantUserID *primitive.ObjectID
+		wantEmail  string
+		wantStatus int
+	}{
+		{
+			name:       "new user signs up",
+			identities: map[string]models.VerifiedIdentity{"t-new": {SocialID: "g-new", Email: "new@example.com"}},
+			token:      "t-new",
+			wantNew:    true,
+			wantEmail:  "new@example.com",
+		},
+		{
+			name:       "existing user logs in",
+			identities: map[string]models.VerifiedIdentity{"t-old": {SocialID: "g-existing", Email: "changed@example.com"}},
+			token:      "t-old",
+			wantNew:    false,
+			wantUserID: &existing.ID,
+		},
+		{
+			name:       "missing email still signs up",
+			identities: map[string]models.VerifiedIdentity{"t-noemail": {SocialID: "g-noemail"}},
+			token:      "t-noemail",
+			wantNew:    true,
+			wantEmail:  "",
+		},
+		{
+			name:       "missing subject is rejected",
+			identities: map[string]models.VerifiedIdentity{"t-nosub": {Email: "x@example.com"}},
+			token:      "t-nosub",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown token is rejected",
+			token:      "t-unknown",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := *existing
+			users := newMemUserRepo(&user)
+			s := newTestAuthService(users, NewFakeIdentityProvider(models.ProviderGoogle, tt.identities))
+
+			isNew, tokens, got, err := s.LoginWithGoogle(context.Background(), tt.token, models.ClientInfo{})
+			if tt.wantStatus != 0 {
+				if statusOf(err) != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if isNew != tt.wantNew {
+				t.Errorf("isNew = %v, want %v", isNew, tt.wantNew)
+			}
+			if tokens == nil || tokens.AccessToken == "" || tokens.RefreshToken == "" {
+				t.Errorf("tokens not issued: %+v", tokens)
+			}
+			if tt.wantUserID != nil && got.ID != *tt.wantUserID {
+				t.Errorf("user = %s, want %s", got.ID.Hex(), tt.wantUserID.Hex())
+			}
+			if tt.wantNew {
+				if len(users.users) != 2 {
+					t.Errorf("user count = %d, want 2", len(users.users))
+				}
+				if got.SocialEmail != tt.wantEmail {
+					t.Errorf("social email = %q, want %q", got.SocialEmail, tt.wantEmail)
+				}
+				if len(got.Identities) != 1 || got.Identities[0].Provider != models.ProviderGoogle {
+					t.Errorf("identities = %+v", got.Identities)
+				}
+			} else if len(users.users) != 1 {
+				t.Errorf("user count = %d, want 1", len(users.users))
+			}
+		})
+	}
+}
+
+func TestLoginWithSocialProviderError(t *testing.T) {
+	fake := NewFakeIdentityProvider(models.ProviderKakao, nil)
+	fake.Err = apperr.ServiceUnavailable("kakao is down", nil)
+	s := newTestAuthService(newMemUserRepo(), fake)
+
+	_, _, _, err := s.LoginWithKakao(context.Background(), "anything", models.ClientInfo{})
+	if statusOf(err) != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want 503", err)
+	}
+
+	// 등록되지 않은 제공자
+	_, _, _, err = s.LoginWithApple(context.Background(), "anything", models.ClientInfo{})
+	if statusOf(err) != http.StatusBadRequest {
+		t.Fatalf("err = %v, want 400", err)
+	}
+}
+
+func TestLinkSocialIdentity(t *testing.T) {
+	owner := &models.User{
+		ID:         primitive.NewObjectID(),
+		Provider:   models.ProviderKakao,
+		Identities: []models.Identity{newIdentity(models.ProviderKakao, "k-owner", "")},
+	}
+	me := &models.User{
+		ID:         primitive.NewObjectID(),
+		Provider:   models.ProviderLocal,
+		Username:   "me",
+		Identities: []models.Identity{newIdentity(models.ProviderLocal, "me", "")},
+	}
+	kakao := NewFakeIdentityProvider(models.ProviderKakao, map[string]models.VerifiedIdentity{
+		"t-owner": {SocialID: "k-owner"},
+		"t-free":  {SocialID: "k-free"},
+	})
+
+	tests := []struct {
+		name       string
+		userID     primitive.ObjectID
+		token      string
+		wantStatus int
+	}{
+		{"linked to another user", me.ID, "t-owner", http.StatusConflict},
+		{"same provider already linked to self", owner.ID, "t-owner", http.StatusConflict},
+		{"provider slot already taken", owner.ID, "t-free", http.StatusConflict},
+		{"links free identity", me.ID, "t-free", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, m := *owner, *me
+			o.Identities = append([]models.Identity(nil), owner.Identities...)
+			m.Identities = append([]models.Identity(nil), me.Identities...)
+			s := newTestAuthService(newMemUserRepo(&o, &m), kakao)
+
+			err := s.LinkSocialIdentity(context.Background(), tt.userID.Hex(), models.ProviderKakao, tt.token)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(m.Identities) != 2 {
+					t.Errorf("identities = %+v, want local and kakao", m.Identities)
+				}
+				return
+			}
+			if statusOf(err) != tt.wantStatus {
+				t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
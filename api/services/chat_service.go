@@ -4,6 +4,7 @@ package services
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/seojoonrp/bbiyong-backend/api/repositories"
@@ -14,7 +15,7 @@ import (
 
 type ChatService interface {
 	SaveMessage(ctx context.Context, meetingID, userID string, content, name, profile string) (*models.ChatMessage, error)
-	SaveSystemMessage(ctx context.Context, meetingID, userID string, eventType string) (*models.ChatMessage, error)
+	SaveSystemMessage(ctx context.Context, event models.MeetingEvent) (*models.ChatMessage, error)
 	GetChatHistory(ctx context.Context, meetingID string, limit int64) ([]models.ChatMessage, error)
 }
 
@@ -39,6 +40,22 @@ func (s *chatService) SaveMessage(ctx context.Context, meetingID, userID string,
 		return nil, apperr.BadRequest("invalid meeting ID format", err)
 	}
 
+	// 취소된 모임의 채팅방은 읽기 전용
+	meeting, err := s.meetingRepo.FindByID(ctx, mID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch meeting", err)
+	}
+	if meeting == nil {
+		return nil, apperr.NotFound("meeting not found", nil)
+	}
+	if meeting.Status == models.MeetingStatusCancelled {
+		return nil, apperr.Forbidden("chat room is read-only", nil)
+	}
+	// 강퇴되거나 나간 직후 연결이 끊기기 전에 보낸 메시지
+	if !containsID(meeting.ParticipantIDs, uID) {
+		return nil, apperr.Forbidden("not a participant of this meeting", nil)
+	}
+
 	msg := &models.ChatMessage{
 		ID:               primitive.NewObjectID(),
 		MeetingID:        mID,
@@ -58,33 +75,69 @@ func (s *chatService) SaveMessage(ctx context.Context, meetingID, userID string,
 	return msg, nil
 }
 
-func (s *chatService) SaveSystemMessage(ctx context.Context, meetingID, userID string, eventType string) (*models.ChatMessage, error) {
-	uID, err := primitive.ObjectIDFromHex(userID)
-	if err != nil {
-		return nil, apperr.InternalServerError("invalid user ID in token", err)
-	}
-
-	mID, err := primitive.ObjectIDFromHex(meetingID)
+func (s *chatService) SaveSystemMessage(ctx context.Context, event models.MeetingEvent) (*models.ChatMessage, error) {
+	mID, err := primitive.ObjectIDFromHex(event.MeetingID)
 	if err != nil {
 		return nil, apperr.BadRequest("invalid meeting ID format", err)
 	}
 
-	user, err := s.userRepo.FindByID(ctx, uID)
-	if err != nil {
-		return nil, apperr.InternalServerError("failed to fetch user by ID", err)
-	}
-	if user == nil {
-		return nil, apperr.NotFound("user not found", nil)
+	// 스케줄러가 만든 이벤트처럼 유저가 없으면 sender_id는 비워 둠
+	var uID primitive.ObjectID
+	user := &models.User{}
+	if event.UserID != "" {
+		uID, err = primitive.ObjectIDFromHex(event.UserID)
+		if err != nil {
+			return nil, apperr.InternalServerError("invalid user ID in token", err)
+		}
+
+		user, err = s.userRepo.FindByID(ctx, uID)
+		if err != nil {
+			return nil, apperr.InternalServerError("failed to fetch user by ID", err)
+		}
+		if user == nil {
+			return nil, apperr.NotFound("user not found", nil)
+		}
 	}
 
 	var content, chatType string
-	switch eventType {
+	switch event.Type {
 	case models.EventJoinMeeting:
 		content = user.Nickname + "님이 참여했습니다."
 		chatType = models.ChatTypeJoin
 	case models.EventLeaveMeeting:
 		content = user.Nickname + "님이 나갔습니다."
 		chatType = models.ChatTypeLeave
+	case models.EventCancelMeeting:
+		// 공동 방장도 취소할 수 있으므로 취소한 사람 이름으로
+		content = "모임이 취소되었습니다."
+		if user.Nickname != "" {
+			content = user.Nickname + "님이 모임을 취소했습니다."
+		}
+		if event.Reason != "" {
+			content += " (사유: " + event.Reason + ")"
+		}
+		chatType = models.ChatTypeCancel
+	case models.EventStartMeeting:
+		content = "모임 시간이 되었습니다. 즐거운 모임 되세요!"
+		chatType = models.ChatTypeStart
+	case models.EventFinishMeeting:
+		content = "모임이 종료되었습니다."
+		chatType = models.ChatTypeFinish
+	case models.EventTransferHost:
+		content = user.Nickname + "님이 새 방장이 되었습니다."
+		chatType = models.ChatTypeRole
+	case models.EventAddCoHost:
+		content = user.Nickname + "님이 공동 방장이 되었습니다."
+		chatType = models.ChatTypeRole
+	case models.EventRemoveCoHost:
+		content = user.Nickname + "님이 공동 방장에서 해제되었습니다."
+		chatType = models.ChatTypeRole
+	case models.EventKickMember:
+		content = user.Nickname + "님이 모임에서 내보내졌습니다."
+		chatType = models.ChatTypeKick
+	case models.EventUpdateMeeting:
+		content = "모임 정보가 변경되었습니다. (" + strings.Join(event.Changes, ", ") + ")"
+		chatType = models.ChatTypeUpdate
 	default:
 		content = "알 수 없는 이벤트가 발생했습니다."
 		chatType = "unknown"
@@ -127,5 +180,46 @@ func (s *chatService) GetChatHistory(ctx context.Context, meetingID string, limi
 		return nil, apperr.InternalServerError("failed to get chat history", err)
 	}
 
+	if err := s.resolveSenders(ctx, history); err != nil {
+		return nil, err
+	}
+
 	return history, nil
 }
+
+// 메시지에 저장된 이름과 사진은 보낸 시점 값이라 읽을 때 현재 프로필로 덮어씀.
+// 탈퇴했거나 탈퇴 유예 중인 유저는 저장된 값을 그대로 둠
+func (s *chatService) resolveSenders(ctx context.Context, history []models.ChatMessage) error {
+	seen := make(map[primitive.ObjectID]bool)
+	var ids []primitive.ObjectID
+	for _, msg := range history {
+		if msg.Type != models.ChatTypeTalk || seen[msg.SenderID] {
+			continue
+		}
+		seen[msg.SenderID] = true
+		ids = append(ids, msg.SenderID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	summaries, err := s.userRepo.FindSummaries(ctx, ids, 0)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch senders", err)
+	}
+	byID := make(map[primitive.ObjectID]models.UserSummary, len(summaries))
+	for _, summary := range summaries {
+		byID[summary.ID] = summary
+	}
+
+	for i := range history {
+		if history[i].Type != models.ChatTypeTalk {
+			continue
+		}
+		if summary, ok := byID[history[i].SenderID]; ok {
+			history[i].SenderName = summary.Nickname
+			history[i].SenderProfileURI = summary.ProfileURI
+		}
+	}
+	return nil
+}
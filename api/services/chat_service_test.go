@@ -0,0 +1,63 @@
+// api/services/chat_service_test.go
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memChatRepo struct {
+	repositories.ChatRepository
+	messages []models.ChatMessage
+}
+
+func (r *memChatRepo) GetChatHistory(ctx context.Context, meetingID primitive.ObjectID, limit int64) ([]models.ChatMessage, error) {
+	history := make([]models.ChatMessage, len(r.messages))
+	copy(history, r.messages)
+	return history, nil
+}
+
+func (r *memUserRepo) FindSummaries(ctx context.Context, ids []primitive.ObjectID, limit int64) ([]models.UserSummary, error) {
+	var summaries []models.UserSummary
+	for _, id := range ids {
+		u, ok := r.users[id]
+		if !ok || u.DeletionScheduledAt != nil {
+			continue
+		}
+		summaries = append(summaries, models.UserSummary{ID: u.ID, Nickname: u.Nickname, ProfileURI: u.ProfileURI})
+	}
+	return summaries, nil
+}
+
+func TestGetChatHistoryResolvesSenders(t *testing.T) {
+	renamed := &models.User{ID: primitive.NewObjectID(), Nickname: "새이름", ProfileURI: "new.png"}
+	goneID := primitive.NewObjectID()
+	meetingID := primitive.NewObjectID()
+
+	repo := &memChatRepo{messages: []models.ChatMessage{
+		{SenderID: renamed.ID, SenderName: "옛이름", SenderProfileURI: "old.png", Type: models.ChatTypeTalk},
+		{SenderID: goneID, SenderName: "탈퇴한 사용자", Type: models.ChatTypeTalk},
+		{SenderName: "System", Type: models.ChatTypeJoin},
+	}}
+	s := &chatService{chatRepo: repo, userRepo: newMemUserRepo(renamed)}
+
+	history, err := s.GetChatHistory(context.Background(), meetingID.Hex(), 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if history[0].SenderName != "새이름" || history[0].SenderProfileURI != "new.png" {
+		t.Errorf("renamed sender = %q %q", history[0].SenderName, history[0].SenderProfileURI)
+	}
+	if history[1].SenderName != "탈퇴한 사용자" {
+		t.Errorf("deleted sender = %q, want stored name", history[1].SenderName)
+	}
+	if history[2].SenderName != "System" {
+		t.Errorf("system sender = %q", history[2].SenderName)
+	}
+}
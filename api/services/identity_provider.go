@@ -0,0 +1,136 @@
+// api/services/identity_provider.go
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"cloud.google.com/go/auth/credentials/idtoken"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/jwks"
+	"github.com/seojoonrp/bbiyong-backend/models"
+)
+
+// 소셜 로그인 토큰을 검증해서 소셜 계정 식별자를 돌려줌.
+// authService는 이 인터페이스에만 의존하므로 테스트에서는 가짜 구현을 꽂으면 됨
+type IdentityProvider interface {
+	Provider() string
+	Verify(ctx context.Context, credential string) (*models.VerifiedIdentity, error)
+}
+
+type googleIdentityProvider struct {
+	clientID string
+}
+
+func NewGoogleIdentityProvider(clientID string) IdentityProvider {
+	return &googleIdentityProvider{clientID: clientID}
+}
+
+func (p *googleIdentityProvider) Provider() string {
+	return models.ProviderGoogle
+}
+
+func (p *googleIdentityProvider) Verify(ctx context.Context, idToken string) (*models.VerifiedIdentity, error) {
+	payload, err := idtoken.Validate(ctx, idToken, p.clientID)
+	if err != nil {
+		return nil, apperr.Unauthorized("invalid Google ID token", err)
+	}
+
+	email, _ := payload.Claims["email"].(string)
+	return &models.VerifiedIdentity{SocialID: payload.Subject, Email: email}, nil
+}
+
+type kakaoIdentityProvider struct {
+	userInfoURL string
+	httpClient  *http.Client
+}
+
+func NewKakaoIdentityProvider(userInfoURL string, httpClient *http.Client) IdentityProvider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &kakaoIdentityProvider{userInfoURL: userInfoURL, httpClient: httpClient}
+}
+
+func (p *kakaoIdentityProvider) Provider() string {
+	return models.ProviderKakao
+}
+
+func (p *kakaoIdentityProvider) Verify(ctx context.Context, accessToken string) (*models.VerifiedIdentity, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.userInfoURL, nil)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to build kakao request", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, apperr.ServiceUnavailable("kakao api server unreachable", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, apperr.Unauthorized("expired or invalid kakao token", nil)
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, apperr.InternalServerError("kakao api returned error status", fmt.Errorf("status: %d", resp.StatusCode))
+	}
+
+	var kakaoRes struct {
+		ID           int64 `json:"id"`
+		KakaoAccount struct {
+			Email   string `json:"email"`
+			Profile struct {
+				Nickname string `json:"nickname"`
+			} `json:"profile"`
+		} `json:"kakao_account"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&kakaoRes); err != nil {
+		return nil, apperr.InternalServerError("failed to decode Kakao user info", err)
+	}
+
+	return &models.VerifiedIdentity{
+		SocialID: strconv.FormatInt(kakaoRes.ID, 10),
+		Email:    kakaoRes.KakaoAccount.Email,
+	}, nil
+}
+
+type appleIdentityProvider struct {
+	keys     *jwks.Cache
+	clientID string
+}
+
+// 키 캐시는 서버 시작 시 한 번 만들어서 넘겨줌. 로그인마다 키를 받아오지 않음
+func NewAppleIdentityProvider(keys *jwks.Cache, clientID string) IdentityProvider {
+	return &appleIdentityProvider{keys: keys, clientID: clientID}
+}
+
+func (p *appleIdentityProvider) Provider() string {
+	return models.ProviderApple
+}
+
+func (p *appleIdentityProvider) Verify(ctx context.Context, identityToken string) (*models.VerifiedIdentity, error) {
+	token, err := jwt.Parse(identityToken, p.keys.Keyfunc, jwt.WithValidMethods([]string{"RS256", "ES256"}))
+	if err != nil {
+		return nil, apperr.Unauthorized("invalid Apple identity token", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, apperr.Unauthorized("invalid token claims", nil)
+	}
+	if claims["iss"] != p.keys.Issuer() {
+		return nil, apperr.Unauthorized("invalid issuer", nil)
+	}
+	if claims["aud"] != p.clientID {
+		return nil, apperr.Unauthorized("invalid audience", nil)
+	}
+
+	socialID, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+	return &models.VerifiedIdentity{SocialID: socialID, Email: email}, nil
+}
@@ -0,0 +1,50 @@
+// api/services/identity_provider_fake_test.go
+
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+)
+
+// 네트워크 없이 소셜 로그인을 흉내내는 가짜 구현. 테스트 전용
+//
+// Identities에 등록된 토큰은 그대로 매핑하고,
+// AcceptAny가 켜져 있으면 "socialID" 또는 "socialID:email" 형식의 토큰을 그대로 받아줌
+type FakeIdentityProvider struct {
+	Name       string
+	Identities map[string]models.VerifiedIdentity
+	AcceptAny  bool
+	Err        error // 설정하면 모든 검증이 이 에러로 실패
+}
+
+func NewFakeIdentityProvider(provider string, identities map[string]models.VerifiedIdentity) *FakeIdentityProvider {
+	if identities == nil {
+		identities = make(map[string]models.VerifiedIdentity)
+	}
+	return &FakeIdentityProvider{Name: provider, Identities: identities}
+}
+
+func (p *FakeIdentityProvider) Provider() string {
+	return p.Name
+}
+
+func (p *FakeIdentityProvider) Verify(ctx context.Context, credential string) (*models.VerifiedIdentity, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	if identity, ok := p.Identities[credential]; ok {
+		return &identity, nil
+	}
+
+	if p.AcceptAny && credential != "" {
+		socialID, email, _ := strings.Cut(credential, ":")
+		return &models.VerifiedIdentity{SocialID: socialID, Email: email}, nil
+	}
+
+	return nil, apperr.Unauthorized("invalid "+strings.ToLower(p.Name)+" token", nil)
+}
@@ -0,0 +1,220 @@
+// api/services/join_request_service.go
+
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 승인제 모임의 참여 신청. 신청은 MeetingService.JoinMeeting을 거쳐서 들어옴
+type JoinRequestService interface {
+	Submit(ctx context.Context, meeting *models.Meeting, userID primitive.ObjectID, message string) (*models.JoinRequest, error)
+	ListPending(ctx context.Context, meetingID, userID string) ([]models.JoinRequestView, error)
+	Approve(ctx context.Context, meetingID, requestID, userID string) error
+	Reject(ctx context.Context, meetingID, requestID, userID string) error
+	Cancel(ctx context.Context, meetingID, userID string) error
+}
+
+type joinRequestService struct {
+	joinRequestRepo repositories.JoinRequestRepository
+	meetingRepo     repositories.MeetingRepository
+	userRepo        repositories.UserRepository
+	eventChan       chan<- models.MeetingEvent
+}
+
+func NewJoinRequestService(jrr repositories.JoinRequestRepository, mr repositories.MeetingRepository, ur repositories.UserRepository, ec chan<- models.MeetingEvent) JoinRequestService {
+	return &joinRequestService{joinRequestRepo: jrr, meetingRepo: mr, userRepo: ur, eventChan: ec}
+}
+
+func (s *joinRequestService) Submit(ctx context.Context, meeting *models.Meeting, userID primitive.ObjectID, message string) (*models.JoinRequest, error) {
+	message = strings.TrimSpace(message)
+	if utf8.RuneCountInString(message) > 200 {
+		return nil, apperr.BadRequest("message must be at most 200 characters", nil)
+	}
+
+	req := &models.JoinRequest{
+		MeetingID: meeting.ID,
+		UserID:    userID,
+		Message:   message,
+		Status:    models.JoinRequestPending,
+		CreatedAt: time.Now(),
+	}
+
+	// 대기 중인 신청은 모임-유저 쌍마다 하나 (부분 유니크 인덱스)
+	if err := s.joinRequestRepo.Create(ctx, req); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperr.Conflict("join request already pending", err)
+		}
+		return nil, apperr.InternalServerError("failed to create join request", err)
+	}
+
+	return req, nil
+}
+
+func (s *joinRequestService) ListPending(ctx context.Context, meetingID, userID string) ([]models.JoinRequestView, error) {
+	meeting, uID, err := s.loadMeeting(ctx, meetingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !meeting.CanManage(uID) {
+		return nil, apperr.Forbidden("only the host or co-hosts can view join requests", nil)
+	}
+
+	requests, err := s.joinRequestRepo.ListPendingByMeeting(ctx, meeting.ID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch join requests", err)
+	}
+	return requests, nil
+}
+
+// 신청을 먼저 승인 상태로 잡고 참여자를 추가함. 정원이 차서 실패하면 다시 대기로 돌림
+func (s *joinRequestService) Approve(ctx context.Context, meetingID, requestID, userID string) error {
+	meeting, uID, err := s.loadMeeting(ctx, meetingID, userID)
+	if err != nil {
+		return err
+	}
+	req, err := s.loadPendingRequest(ctx, meeting, uID, requestID)
+	if err != nil {
+		return err
+	}
+
+	// 신청한 뒤에 나이 제한이 바뀌었을 수 있음
+	applicant, err := findUser(ctx, s.userRepo, req.UserID)
+	if err != nil {
+		return err
+	}
+	if reason := eligibilityReason(meeting, applicant); reason != "" {
+		return joinBlockedError(reason)
+	}
+
+	claimed, err := s.joinRequestRepo.UpdateStatus(ctx, req.ID, models.JoinRequestPending, models.JoinRequestApproved, &uID, time.Now())
+	if err != nil {
+		return apperr.InternalServerError("failed to approve join request", err)
+	}
+	if !claimed {
+		return apperr.Conflict("join request is no longer pending", nil)
+	}
+
+	success, err := s.meetingRepo.AddParticipant(ctx, meeting.ID, req.UserID, applicant.Gender)
+	if err != nil || !success {
+		if _, revertErr := s.joinRequestRepo.UpdateStatus(ctx, req.ID, models.JoinRequestApproved, models.JoinRequestPending, nil, time.Now()); revertErr != nil {
+			log.Printf("Failed to revert join request %s to pending: %v", req.ID.Hex(), revertErr)
+		}
+		if err != nil {
+			return apperr.InternalServerError("failed to add participant", err)
+		}
+		if meeting.IsGenderFull(applicant.Gender) {
+			return apperr.Conflict("no spots left for the applicant's gender", nil).WithCode(apperr.CodeGenderQuotaFull)
+		}
+		return apperr.Conflict("meeting is full or no longer recruiting", nil)
+	}
+
+	s.eventChan <- models.MeetingEvent{
+		Type:      models.EventJoinMeeting,
+		MeetingID: meetingID,
+		UserID:    req.UserID.Hex(),
+	}
+	return nil
+}
+
+func (s *joinRequestService) Reject(ctx context.Context, meetingID, requestID, userID string) error {
+	meeting, uID, err := s.loadMeeting(ctx, meetingID, userID)
+	if err != nil {
+		return err
+	}
+	req, err := s.loadPendingRequest(ctx, meeting, uID, requestID)
+	if err != nil {
+		return err
+	}
+
+	success, err := s.joinRequestRepo.UpdateStatus(ctx, req.ID, models.JoinRequestPending, models.JoinRequestRejected, &uID, time.Now())
+	if err != nil {
+		return apperr.InternalServerError("failed to reject join request", err)
+	}
+	if !success {
+		return apperr.Conflict("join request is no longer pending", nil)
+	}
+	return nil
+}
+
+// 신청자 본인이 취소
+func (s *joinRequestService) Cancel(ctx context.Context, meetingID, userID string) error {
+	meeting, uID, err := s.loadMeeting(ctx, meetingID, userID)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.joinRequestRepo.FindPending(ctx, meeting.ID, uID)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch join request", err)
+	}
+	if req == nil {
+		return apperr.NotFound("no pending join request", nil)
+	}
+
+	success, err := s.joinRequestRepo.UpdateStatus(ctx, req.ID, models.JoinRequestPending, models.JoinRequestCancelled, nil, time.Now())
+	if err != nil {
+		return apperr.InternalServerError("failed to cancel join request", err)
+	}
+	if !success {
+		return apperr.Conflict("join request is no longer pending", nil)
+	}
+	return nil
+}
+
+func (s *joinRequestService) loadMeeting(ctx context.Context, meetingID, userID string) (*models.Meeting, primitive.ObjectID, error) {
+	var zero primitive.ObjectID
+
+	mID, err := primitive.ObjectIDFromHex(meetingID)
+	if err != nil {
+		return nil, zero, apperr.BadRequest("invalid meeting ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, zero, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	meeting, err := s.meetingRepo.FindByID(ctx, mID)
+	if err != nil {
+		return nil, zero, apperr.InternalServerError("failed to fetch meeting", err)
+	}
+	if meeting == nil {
+		return nil, zero, apperr.NotFound("meeting not found", nil)
+	}
+	return meeting, uID, nil
+}
+
+// 방장이나 공동 방장이 이 모임의 대기 중인 신청을 처리할 때
+func (s *joinRequestService) loadPendingRequest(ctx context.Context, meeting *models.Meeting, userID primitive.ObjectID, requestID string) (*models.JoinRequest, error) {
+	if !meeting.CanManage(userID) {
+		return nil, apperr.Forbidden("only the host or co-hosts can handle join requests", nil)
+	}
+
+	rID, err := primitive.ObjectIDFromHex(requestID)
+	if err != nil {
+		return nil, apperr.BadRequest("invalid join request ID format", err)
+	}
+
+	req, err := s.joinRequestRepo.FindByID(ctx, rID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch join request", err)
+	}
+	if req == nil || req.MeetingID != meeting.ID {
+		return nil, apperr.NotFound("join request not found", nil)
+	}
+	if req.Status != models.JoinRequestPending {
+		return nil, apperr.Conflict("join request is no longer pending", nil)
+	}
+	return req, nil
+}
@@ -0,0 +1,133 @@
+// api/services/login_throttle_service.go
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 로컬 로그인 실패를 아이디별, IP별로 세서 임계값을 넘으면 지수적으로 늘어나는 시간만큼 잠금.
+// 존재하지 않는 아이디도 똑같이 세야 계정 존재 여부가 드러나지 않음
+type LoginThrottleService interface {
+	Check(ctx context.Context, username, ip string) error
+	RecordFailure(ctx context.Context, username, ip string, userID *primitive.ObjectID)
+	RecordSuccess(ctx context.Context, username string)
+}
+
+type loginThrottleService struct {
+	attemptRepo  repositories.LoginAttemptRepository
+	auditLogRepo repositories.AuditLogRepository
+}
+
+func NewLoginThrottleService(lar repositories.LoginAttemptRepository, alr repositories.AuditLogRepository) LoginThrottleService {
+	return &loginThrottleService{
+		attemptRepo:  lar,
+		auditLogRepo: alr,
+	}
+}
+
+func usernameAttemptKey(username string) string { return "user:" + username }
+func ipAttemptKey(ip string) string             { return "ip:" + ip }
+
+func (s *loginThrottleService) Check(ctx context.Context, username, ip string) error {
+	now := time.Now()
+
+	for _, key := range []string{usernameAttemptKey(username), ipAttemptKey(ip)} {
+		attempt, err := s.attemptRepo.Find(ctx, key)
+		if err != nil {
+			return apperr.InternalServerError("failed to check login attempts", err)
+		}
+		if attempt != nil && attempt.LockedUntil.After(now) {
+			retryAfter := int(math.Ceil(attempt.LockedUntil.Sub(now).Seconds()))
+			return apperr.TooManyRequests("too many failed login attempts, try again later", nil).
+				WithHeader("Retry-After", strconv.Itoa(retryAfter))
+		}
+	}
+
+	return nil
+}
+
+// 카운터 저장에 실패해도 로그인 응답 자체는 그대로 돌려줌
+func (s *loginThrottleService) RecordFailure(ctx context.Context, username, ip string, userID *primitive.ObjectID) {
+	cfg := config.AppConfig
+
+	s.recordFailure(ctx, usernameAttemptKey(username), cfg.LoginMaxFailuresPerUser, func(until time.Time) *models.AuditLog {
+		return &models.AuditLog{
+			Action:  models.AuditActionAccountLocked,
+			UserID:  userID,
+			Subject: username,
+			IP:      ip,
+			Detail:  fmt.Sprintf("locked until %s", until.Format(time.RFC3339)),
+		}
+	})
+
+	// 한 IP 뒤에 여러 사용자가 있을 수 있어 IP 임계값은 더 넉넉하게 둠
+	s.recordFailure(ctx, ipAttemptKey(ip), cfg.LoginMaxFailuresPerIP, func(until time.Time) *models.AuditLog {
+		return &models.AuditLog{
+			Action:  models.AuditActionIPLocked,
+			Subject: ip,
+			IP:      ip,
+			Detail:  fmt.Sprintf("locked until %s (last username %q)", until.Format(time.RFC3339), username),
+		}
+	})
+}
+
+func (s *loginThrottleService) recordFailure(ctx context.Context, key string, threshold int, audit func(until time.Time) *models.AuditLog) {
+	cfg := config.AppConfig
+	now := time.Now()
+	retention := cfg.LoginFailureWindow + cfg.LoginLockoutMax
+
+	attempt, err := s.attemptRepo.RecordFailure(ctx, key, now, cfg.LoginFailureWindow, retention)
+	if err != nil {
+		log.Printf("Failed to record login failure for %s: %v", key, err)
+		return
+	}
+	if attempt.Failures < threshold {
+		return
+	}
+
+	until := now.Add(lockoutDuration(attempt.Failures - threshold))
+	if err := s.attemptRepo.Lock(ctx, key, until); err != nil {
+		log.Printf("Failed to lock %s: %v", key, err)
+		return
+	}
+
+	// 잠금이 새로 걸릴 때만 기록. 잠금 중에는 Check에서 막히므로 여기까지 오지 않음
+	entry := audit(until)
+	entry.CreatedAt = now
+	if err := s.auditLogRepo.Create(ctx, entry); err != nil {
+		log.Printf("Failed to write audit log for %s: %v", key, err)
+	}
+}
+
+// 임계값을 넘긴 뒤 실패할 때마다 base, 2*base, 4*base ... 최대 LoginLockoutMax
+func lockoutDuration(excess int) time.Duration {
+	cfg := config.AppConfig
+
+	d := cfg.LoginLockoutBase
+	for i := 0; i < excess; i++ {
+		d *= 2
+		if d >= cfg.LoginLockoutMax {
+			return cfg.LoginLockoutMax
+		}
+	}
+	return min(d, cfg.LoginLockoutMax)
+}
+
+// 성공하면 아이디 카운터만 초기화. IP 카운터까지 지우면 계정 하나로 다른 계정 대입을 이어갈 수 있음
+func (s *loginThrottleService) RecordSuccess(ctx context.Context, username string) {
+	if err := s.attemptRepo.Reset(ctx, usernameAttemptKey(username)); err != nil {
+		log.Printf("Failed to reset login attempts for %s: %v", username, err)
+	}
+}
@@ -0,0 +1,48 @@
+// api/services/login_throttle_service_test.go
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/config"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.LoginLockoutBase = time.Minute
+	config.AppConfig.LoginLockoutMax = 10 * time.Minute
+
+	tests := []struct {
+		name   string
+		excess int
+		want   time.Duration
+	}{
+		{"at threshold", 0, time.Minute},
+		{"one over", 1, 2 * time.Minute},
+		{"three over", 3, 8 * time.Minute},
+		{"capped", 4, 10 * time.Minute},
+		{"far over stays capped", 100, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lockoutDuration(tt.excess); got != tt.want {
+				t.Errorf("lockoutDuration(%d) = %v, want %v", tt.excess, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockoutDurationBaseAboveMax(t *testing.T) {
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.LoginLockoutBase = time.Hour
+	config.AppConfig.LoginLockoutMax = 30 * time.Minute
+
+	if got := lockoutDuration(0); got != 30*time.Minute {
+		t.Errorf("lockoutDuration(0) = %v, want %v", got, 30*time.Minute)
+	}
+}
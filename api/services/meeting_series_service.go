@@ -0,0 +1,352 @@
+// api/services/meeting_series_service.go
+
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MeetingSeriesService interface {
+	CreateSeries(ctx context.Context, hostID string, req models.CreateMeetingSeriesRequest) (*models.MeetingSeries, error)
+	GetSeries(ctx context.Context, seriesID, userID string) (*models.MeetingSeriesDetail, error)
+	// 고정 멤버가 되면 이미 열린 회차와 앞으로 만들어질 회차에 자동으로 참여함
+	OptIn(ctx context.Context, seriesID, userID string) error
+	OptOut(ctx context.Context, seriesID, userID string) error
+	// SeriesLookahead 안에 열리는 회차를 미리 만들어 둠. 만든 회차 수를 돌려줌
+	MaterializeDue(ctx context.Context, now time.Time) (int, error)
+}
+
+const (
+	maxSeriesOccurrences = 52
+	seriesBatchSize      = 100
+)
+
+type meetingSeriesService struct {
+	seriesRepo  repositories.MeetingSeriesRepository
+	meetingRepo repositories.MeetingRepository
+	userRepo    repositories.UserRepository
+	eventChan   chan<- models.MeetingEvent
+}
+
+func NewMeetingSeriesService(msr repositories.MeetingSeriesRepository, mr repositories.MeetingRepository, ur repositories.UserRepository, ec chan<- models.MeetingEvent) MeetingSeriesService {
+	return &meetingSeriesService{seriesRepo: msr, meetingRepo: mr, userRepo: ur, eventChan: ec}
+}
+
+func (s *meetingSeriesService) CreateSeries(ctx context.Context, hostID string, req models.CreateMeetingSeriesRequest) (*models.MeetingSeries, error) {
+	hID, err := primitive.ObjectIDFromHex(hostID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	host, err := findUser(ctx, s.userRepo, hID)
+	if err != nil {
+		return nil, err
+	}
+
+	joinMode, err := validateNewMeeting(req.CreateMeetingRequest, host)
+	if err != nil {
+		return nil, err
+	}
+	if !req.MeetingTime.After(time.Now()) {
+		return nil, apperr.BadRequest("meeting time must be in the future", nil)
+	}
+	if err := validateRecurrence(req.Recurrence, req.MeetingTime); err != nil {
+		return nil, err
+	}
+
+	series := models.MeetingSeries{
+		Title:           req.Title,
+		Description:     req.Description,
+		Category:        req.Category,
+		ImageURL:        req.ImageURL,
+		PlaceName:       req.PlaceName,
+		Location:        req.Location,
+		AgeRange:        req.AgeRange,
+		GenderQuota:     req.GenderQuota,
+		MaxParticipants: req.MaxParticipants,
+		JoinMode:        joinMode,
+		HostID:          hID,
+		MemberIDs:       []primitive.ObjectID{},
+		Recurrence:      req.Recurrence,
+		StartTime:       req.MeetingTime,
+		NextOccurrence:  req.MeetingTime,
+		Status:          models.SeriesStatusActive,
+		CreatedAt:       time.Now(),
+	}
+
+	if err := s.seriesRepo.Create(ctx, &series); err != nil {
+		return nil, apperr.InternalServerError("failed to create meeting series", err)
+	}
+
+	// 첫 회차들은 바로 만듦. 실패해도 작업이 다음 주기에 이어서 만듦
+	if _, err := s.materialize(ctx, &series, time.Now()); err != nil {
+		log.Printf("Failed to materialize series %s: %v", series.ID.Hex(), err)
+	}
+
+	return &series, nil
+}
+
+func validateRecurrence(rule models.RecurrenceRule, start time.Time) error {
+	if rule.Frequency != models.RecurrenceWeekly && rule.Frequency != models.RecurrenceBiweekly {
+		return apperr.BadRequest("recurrence frequency must be WEEKLY or BIWEEKLY", nil)
+	}
+	if (rule.Until == nil) == (rule.Count == 0) {
+		return apperr.BadRequest("recurrence needs exactly one of until or count", nil)
+	}
+	if rule.Count < 0 || rule.Count > maxSeriesOccurrences {
+		return apperr.BadRequest("recurrence count must be between 1 and 52", nil)
+	}
+	if rule.Until != nil {
+		if rule.Until.Before(start) {
+			return apperr.BadRequest("recurrence end must be after the first meeting", nil)
+		}
+		if rule.Until.After(start.AddDate(1, 0, 0)) {
+			return apperr.BadRequest("recurrence cannot last longer than a year", nil)
+		}
+	}
+	return nil
+}
+
+func (s *meetingSeriesService) GetSeries(ctx context.Context, seriesID, userID string) (*models.MeetingSeriesDetail, error) {
+	series, uID, err := s.loadSeries(ctx, seriesID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeStatuses := []string{models.MeetingStatusRecruiting, models.MeetingStatusFull, models.MeetingStatusOngoing}
+	occurrences, err := s.meetingRepo.FindBySeries(ctx, series.ID, 0, activeStatuses)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch series occurrences", err)
+	}
+	if occurrences == nil {
+		occurrences = []models.Meeting{}
+	}
+
+	return &models.MeetingSeriesDetail{
+		MeetingSeries: *series,
+		Occurrences:   occurrences,
+		IsMember:      series.IsMember(uID),
+	}, nil
+}
+
+func (s *meetingSeriesService) OptIn(ctx context.Context, seriesID, userID string) error {
+	series, uID, err := s.loadSeries(ctx, seriesID, userID)
+	if err != nil {
+		return err
+	}
+	if series.Status != models.SeriesStatusActive {
+		return apperr.BadRequest("meeting series has ended", nil)
+	}
+	if series.HostID == uID {
+		return apperr.BadRequest("host already joins every occurrence", nil)
+	}
+	if series.IsMember(uID) {
+		return apperr.Conflict("already a member of the series", nil)
+	}
+
+	user, err := findUser(ctx, s.userRepo, uID)
+	if err != nil {
+		return err
+	}
+	template := series.NewOccurrence(series.Materialized, 0)
+	if reason := eligibilityReason(&template, user); reason != "" {
+		return joinBlockedError(reason)
+	}
+
+	// 승인제 시리즈는 한 번이라도 승인받아 참여해 본 사람만 고정 멤버가 될 수 있음
+	if series.JoinMode == models.JoinModeApproval {
+		participated, err := s.meetingRepo.ExistsSeriesParticipant(ctx, series.ID, uID)
+		if err != nil {
+			return apperr.InternalServerError("failed to check series participation", err)
+		}
+		if !participated {
+			return apperr.Forbidden("only past participants can become regular members of this series", nil)
+		}
+	}
+
+	added, err := s.seriesRepo.AddMember(ctx, series.ID, uID, series.MaxParticipants)
+	if err != nil {
+		return apperr.InternalServerError("failed to add series member", err)
+	}
+	if !added {
+		return apperr.BadRequest("meeting series has no member spots left", nil)
+	}
+
+	s.joinOpenOccurrences(ctx, series, user)
+	return nil
+}
+
+// 이미 만들어진 회차에도 참여시킴. 정원이 찼거나 대기자가 있는 회차는 건너뜀
+func (s *meetingSeriesService) joinOpenOccurrences(ctx context.Context, series *models.MeetingSeries, user *models.User) {
+	occurrences, err := s.meetingRepo.FindBySeries(ctx, series.ID, 0, []string{models.MeetingStatusRecruiting})
+	if err != nil {
+		log.Printf("Failed to fetch open occurrences of series %s: %v", series.ID.Hex(), err)
+		return
+	}
+
+	for _, occ := range occurrences {
+		// 회차마다 강퇴되었거나 조건이 바뀌었을 수 있음
+		if containsID(occ.ParticipantIDs, user.ID) || eligibilityReason(&occ, user) != "" {
+			continue
+		}
+		joined, err := s.meetingRepo.AddParticipant(ctx, occ.ID, user.ID, user.Gender)
+		if err != nil {
+			log.Printf("Failed to add series member to meeting %s: %v", occ.ID.Hex(), err)
+			continue
+		}
+		if joined {
+			s.eventChan <- models.MeetingEvent{
+				Type:      models.EventJoinMeeting,
+				MeetingID: occ.ID.Hex(),
+				UserID:    user.ID.Hex(),
+			}
+		}
+	}
+}
+
+// 다음 회차부터 자동 참여만 멈춤. 이미 참여한 회차는 각 모임에서 나가야 함
+func (s *meetingSeriesService) OptOut(ctx context.Context, seriesID, userID string) error {
+	series, uID, err := s.loadSeries(ctx, seriesID, userID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.seriesRepo.RemoveMember(ctx, series.ID, uID)
+	if err != nil {
+		return apperr.InternalServerError("failed to remove series member", err)
+	}
+	if !removed {
+		return apperr.NotFound("not a member of the series", nil)
+	}
+	return nil
+}
+
+func (s *meetingSeriesService) MaterializeDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.seriesRepo.FindDue(ctx, now.Add(config.AppConfig.SeriesLookahead), seriesBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, series := range due {
+		n, err := s.materialize(ctx, &series, now)
+		created += n
+		if err != nil {
+			log.Printf("Failed to materialize series %s: %v", series.ID.Hex(), err)
+		}
+	}
+	return created, nil
+}
+
+// 다음 회차가 lookahead 안에 들어오는 동안 한 회차씩 만들고 넘김
+func (s *meetingSeriesService) materialize(ctx context.Context, series *models.MeetingSeries, now time.Time) (int, error) {
+	host, err := s.userRepo.FindByID(ctx, series.HostID)
+	if err != nil {
+		return 0, err
+	}
+	// 방장이 탈퇴하면 계정 정리 때 시리즈도 끝남
+	if host == nil {
+		return 0, nil
+	}
+
+	horizon := now.Add(config.AppConfig.SeriesLookahead)
+	created := 0
+	for series.Status == models.SeriesStatusActive && !series.NextOccurrence.After(horizon) {
+		n := series.Materialized
+
+		// 서버가 멈춰 있던 사이에 지나간 회차는 만들지 않고 넘김
+		if series.OccurrenceTime(n).After(now) {
+			if err := s.createOccurrence(ctx, series, n, host); err != nil {
+				return created, err
+			}
+			created++
+		}
+
+		next := series.OccurrenceTime(n + 1)
+		ended := series.IsPastEnd(n + 1)
+		advanced, err := s.seriesRepo.Advance(ctx, series.ID, n, next, ended)
+		if err != nil {
+			return created, err
+		}
+		// 다른 곳에서 이미 넘겼거나 틀이 바뀜. 다음 주기에 다시 읽어서 처리
+		if !advanced {
+			break
+		}
+
+		series.Materialized++
+		series.NextOccurrence = next
+		if ended {
+			series.Status = models.SeriesStatusEnded
+		}
+	}
+	return created, nil
+}
+
+// 방장과 고정 멤버를 넣어서 회차를 만듦. 자격이 없어졌거나 자리가 없는 멤버는 이번 회차에서 빠짐
+func (s *meetingSeriesService) createOccurrence(ctx context.Context, series *models.MeetingSeries, n int, host *models.User) error {
+	meetingTime := series.OccurrenceTime(n)
+	meeting := series.NewOccurrence(n, int(meetingTime.In(meetingTimeZone).Weekday()))
+	if host.Gender != "" {
+		meeting.ParticipantGenders = []models.ParticipantGender{{UserID: host.ID, Gender: host.Gender}}
+	}
+
+	for _, memberID := range series.MemberIDs {
+		if len(meeting.ParticipantIDs) >= meeting.MaxParticipants {
+			break
+		}
+
+		member, err := s.userRepo.FindByID(ctx, memberID)
+		if err != nil {
+			return err
+		}
+		if member == nil || eligibilityReason(&meeting, member) != "" || meeting.IsGenderFull(member.Gender) {
+			continue
+		}
+
+		meeting.ParticipantIDs = append(meeting.ParticipantIDs, member.ID)
+		if member.Gender != "" {
+			meeting.ParticipantGenders = append(meeting.ParticipantGenders, models.ParticipantGender{UserID: member.ID, Gender: member.Gender})
+		}
+	}
+	if len(meeting.ParticipantIDs) >= meeting.MaxParticipants {
+		meeting.Status = models.MeetingStatusFull
+	}
+
+	// 회차마다 유니크 인덱스가 있어서 이미 만들어졌으면 넘어감
+	if err := s.meetingRepo.Create(ctx, &meeting); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *meetingSeriesService) loadSeries(ctx context.Context, seriesID, userID string) (*models.MeetingSeries, primitive.ObjectID, error) {
+	var zero primitive.ObjectID
+
+	sID, err := primitive.ObjectIDFromHex(seriesID)
+	if err != nil {
+		return nil, zero, apperr.BadRequest("invalid series ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, zero, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	series, err := s.seriesRepo.FindByID(ctx, sID)
+	if err != nil {
+		return nil, zero, apperr.InternalServerError("failed to fetch meeting series", err)
+	}
+	if series == nil {
+		return nil, zero, apperr.NotFound("meeting series not found", nil)
+	}
+	return series, uID, nil
+}
@@ -0,0 +1,262 @@
+// api/services/meeting_series_service_test.go
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 회차 생성과 틀 수정에 쓰는 메서드만 구현. 조건부 업데이트는 materialized 값으로 흉내냄
+type memSeriesRepo struct {
+	repositories.MeetingSeriesRepository
+	series     *models.MeetingSeries
+	concurrent bool // 조회한 뒤 다른 곳에서 먼저 넘기거나 바꾼 상황
+	updated    bson.M
+}
+
+func (r *memSeriesRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*models.MeetingSeries, error) {
+	if r.series == nil || r.series.ID != id {
+		return nil, nil
+	}
+	copied := *r.series
+	return &copied, nil
+}
+
+func (r *memSeriesRepo) Advance(ctx context.Context, seriesID primitive.ObjectID, from int, next time.Time, ended bool) (bool, error) {
+	if r.concurrent || r.series.Status != models.SeriesStatusActive || r.series.Materialized != from {
+		return false, nil
+	}
+	r.series.Materialized++
+	r.series.NextOccurrence = next
+	if ended {
+		r.series.Status = models.SeriesStatusEnded
+	}
+	return true, nil
+}
+
+func (r *memSeriesRepo) Update(ctx context.Context, seriesID primitive.ObjectID, materialized int, updates bson.M) (bool, error) {
+	if r.concurrent || r.series.Materialized != materialized {
+		return false, nil
+	}
+	r.updated = updates
+	return true, nil
+}
+
+func (r *memMeetingRepo) Create(ctx context.Context, meeting *models.Meeting) error {
+	meeting.ID = primitive.NewObjectID()
+	r.created = append(r.created, *meeting)
+	return nil
+}
+
+func TestValidateRecurrence(t *testing.T) {
+	start := time.Date(2026, 3, 2, 19, 0, 0, 0, time.UTC)
+	at := func(d time.Time) *time.Time { return &d }
+
+	tests := []struct {
+		name string
+		rule models.RecurrenceRule
+		want int
+	}{
+		{"weekly count", models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 4}, 0},
+		{"biweekly until", models.RecurrenceRule{Frequency: models.RecurrenceBiweekly, Until: at(start.AddDate(0, 2, 0))}, 0},
+		{"max count", models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 52}, 0},
+		{"until exactly a year", models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Until: at(start.AddDate(1, 0, 0))}, 0},
+		{"unknown frequency", models.RecurrenceRule{Frequency: "DAILY", Count: 4}, http.StatusBadRequest},
+		{"neither end", models.RecurrenceRule{Frequency: models.RecurrenceWeekly}, http.StatusBadRequest},
+		{"both ends", models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 4, Until: at(start.AddDate(0, 1, 0))}, http.StatusBadRequest},
+		{"count over limit", models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 53}, http.StatusBadRequest},
+		{"negative count", models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: -1}, http.StatusBadRequest},
+		{"until before start", models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Until: at(start.Add(-time.Hour))}, http.StatusBadRequest},
+		{"until over a year", models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Until: at(start.AddDate(1, 0, 1))}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusOf(validateRecurrence(tt.rule, start)); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	host := &models.User{ID: primitive.NewObjectID(), Gender: models.GenderMale, Age: 25}
+
+	tests := []struct {
+		name             string
+		start            time.Time
+		rule             models.RecurrenceRule
+		lookahead        time.Duration
+		concurrent       bool
+		hostGone         bool
+		wantCreated      []int // 만들어진 회차 번호 (1부터)
+		wantMaterialized int
+		wantStatus       string
+	}{
+		{
+			name:             "creates occurrences inside lookahead",
+			start:            now.Add(time.Hour),
+			rule:             models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 10},
+			lookahead:        15 * day,
+			wantCreated:      []int{1, 2, 3},
+			wantMaterialized: 3,
+			wantStatus:       models.SeriesStatusActive,
+		},
+		{
+			name:             "skips occurrences missed while down",
+			start:            now.Add(-15 * day),
+			rule:             models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 10},
+			lookahead:        7 * day,
+			wantCreated:      []int{4},
+			wantMaterialized: 4,
+			wantStatus:       models.SeriesStatusActive,
+		},
+		{
+			name:             "ends after count",
+			start:            now.Add(time.Hour),
+			rule:             models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 2},
+			lookahead:        30 * day,
+			wantCreated:      []int{1, 2},
+			wantMaterialized: 2,
+			wantStatus:       models.SeriesStatusEnded,
+		},
+		{
+			name:             "ends at until",
+			start:            now.Add(time.Hour),
+			rule:             models.RecurrenceRule{Frequency: models.RecurrenceBiweekly, Until: func() *time.Time { u := now.Add(8 * day); return &u }()},
+			lookahead:        30 * day,
+			wantCreated:      []int{1},
+			wantMaterialized: 1,
+			wantStatus:       models.SeriesStatusEnded,
+		},
+		{
+			name:             "stops when advance is lost",
+			start:            now.Add(time.Hour),
+			rule:             models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 10},
+			lookahead:        15 * day,
+			concurrent:       true,
+			wantCreated:      []int{1},
+			wantMaterialized: 0,
+			wantStatus:       models.SeriesStatusActive,
+		},
+		{
+			name:             "host gone",
+			start:            now.Add(time.Hour),
+			rule:             models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 10},
+			lookahead:        15 * day,
+			hostGone:         true,
+			wantCreated:      nil,
+			wantMaterialized: 0,
+			wantStatus:       models.SeriesStatusActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.AppConfig.SeriesLookahead = tt.lookahead
+
+			series := &models.MeetingSeries{
+				ID:              primitive.NewObjectID(),
+				HostID:          host.ID,
+				MaxParticipants: 4,
+				Recurrence:      tt.rule,
+				StartTime:       tt.start,
+				NextOccurrence:  tt.start,
+				Status:          models.SeriesStatusActive,
+			}
+			seriesRepo := &memSeriesRepo{series: series, concurrent: tt.concurrent}
+			meetingRepo := &memMeetingRepo{}
+			users := newMemUserRepo()
+			if !tt.hostGone {
+				users = newMemUserRepo(host)
+			}
+			s := &meetingSeriesService{seriesRepo: seriesRepo, meetingRepo: meetingRepo, userRepo: users}
+
+			// 서비스는 복사본으로 진행 상태를 따라가고 저장소는 Advance로만 바뀜
+			working := *series
+			created, err := s.materialize(context.Background(), &working, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if created != len(tt.wantCreated) || len(meetingRepo.created) != len(tt.wantCreated) {
+				t.Fatalf("created = %d (%d stored), want %d", created, len(meetingRepo.created), len(tt.wantCreated))
+			}
+			for i, m := range meetingRepo.created {
+				if m.Occurrence != tt.wantCreated[i] {
+					t.Errorf("occurrence[%d] = %d, want %d", i, m.Occurrence, tt.wantCreated[i])
+				}
+				if want := series.OccurrenceTime(m.Occurrence - 1); !m.MeetingTime.Equal(want) {
+					t.Errorf("occurrence %d at %v, want %v", m.Occurrence, m.MeetingTime, want)
+				}
+				if !m.MeetingTime.After(now) {
+					t.Errorf("occurrence %d is in the past", m.Occurrence)
+				}
+			}
+			if series.Materialized != tt.wantMaterialized || working.Materialized != tt.wantMaterialized {
+				t.Errorf("materialized = %d (working %d), want %d", series.Materialized, working.Materialized, tt.wantMaterialized)
+			}
+			if series.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", series.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func (r *memMeetingRepo) FindBySeries(ctx context.Context, seriesID primitive.ObjectID, afterOccurrence int, statuses []string) ([]models.Meeting, error) {
+	var found []models.Meeting
+	for _, m := range r.created {
+		if m.SeriesID != nil && *m.SeriesID == seriesID && containsString(statuses, m.Status) {
+			found = append(found, m)
+		}
+	}
+	return found, nil
+}
+
+func (r *memMeetingRepo) AddParticipant(ctx context.Context, meetingID, userID primitive.ObjectID, gender string) (bool, error) {
+	for i := range r.created {
+		if r.created[i].ID == meetingID {
+			r.created[i].ParticipantIDs = append(r.created[i].ParticipantIDs, userID)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestJoinOpenOccurrences(t *testing.T) {
+	seriesID := primitive.NewObjectID()
+	user := &models.User{ID: primitive.NewObjectID(), Gender: models.GenderMale, Age: 25}
+
+	open := models.Meeting{ID: primitive.NewObjectID(), SeriesID: &seriesID, Status: models.MeetingStatusRecruiting}
+	banned := models.Meeting{ID: primitive.NewObjectID(), SeriesID: &seriesID, Status: models.MeetingStatusRecruiting, BannedIDs: []primitive.ObjectID{user.ID}}
+	tooOld := models.Meeting{ID: primitive.NewObjectID(), SeriesID: &seriesID, Status: models.MeetingStatusRecruiting, AgeRange: [2]int{0, 20}}
+	repo := &memMeetingRepo{created: []models.Meeting{open, banned, tooOld}}
+	events := make(chan models.MeetingEvent, 3)
+	s := &meetingSeriesService{meetingRepo: repo, eventChan: events}
+
+	s.joinOpenOccurrences(context.Background(), &models.MeetingSeries{ID: seriesID}, user)
+
+	for _, m := range repo.created {
+		joined := containsID(m.ParticipantIDs, user.ID)
+		if want := m.ID == open.ID; joined != want {
+			t.Errorf("meeting %s joined = %v, want %v", m.ID.Hex(), joined, want)
+		}
+	}
+	if len(events) != 1 {
+		t.Errorf("join events = %d, want 1", len(events))
+	}
+}
@@ -7,29 +7,82 @@ import (
 	"errors"
 	"log"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/seojoonrp/bbiyong-backend/api/repositories"
 	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
 	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type MeetingService interface {
 	CreateMeeting(ctx context.Context, hostID string, req models.CreateMeetingRequest) error
-	GetNearbyMeetings(ctx context.Context, lon, lat float64, radius float64, days []string) ([]models.Meeting, error)
+	GetNearbyMeetings(ctx context.Context, userID string, lon, lat float64, radius float64, days []string, eligibleOnly bool) ([]models.Meeting, error)
 	VerifyParticipation(ctx context.Context, meetingID, userID string) error
-	JoinMeeting(ctx context.Context, meetingID, userID string) error
+	JoinMeeting(ctx context.Context, meetingID, userID, message string) (*models.JoinRequest, error)
 	LeaveMeeting(ctx context.Context, meetingID, userID string) error
+	GetMeetingDetail(ctx context.Context, meetingID, userID string) (*models.MeetingDetail, error)
+	UpdateMeeting(ctx context.Context, meetingID, userID string, req models.UpdateMeetingRequest) (*models.Meeting, error)
+	CancelMeeting(ctx context.Context, meetingID, userID, reason string) error
+	// 탈퇴한 방장의 모임을 취소. 권한 확인만 빼고 CancelMeeting과 같은 흐름
+	CancelForDeletedHost(ctx context.Context, meeting *models.Meeting) error
+	AdvanceLifecycle(ctx context.Context, now time.Time) (started, finished int, err error)
+	TransferHost(ctx context.Context, meetingID, userID, newHostID string) error
+	AddCoHost(ctx context.Context, meetingID, userID, targetID string) error
+	RemoveCoHost(ctx context.Context, meetingID, userID, targetID string) error
+	KickParticipant(ctx context.Context, meetingID, userID, targetID string, ban bool) error
 }
 
+// 한 번 돌 때 상태별로 처리하는 최대 모임 수. 남은 건 다음 주기에
+const lifecycleBatchSize = 200
+
+// 시리즈 틀을 바꾸는 사이 회차 생성 작업과 겹쳤을 때 다시 시도하는 횟수
+const seriesUpdateRetries = 3
+
+// 요일은 한국 시간 기준. 한국은 서머타임이 없어서 고정 오프셋으로 충분함
+var meetingTimeZone = time.FixedZone("KST", 9*60*60)
+
 type meetingService struct {
-	meetingRepo repositories.MeetingRepository
-	eventChan   chan<- models.MeetingEvent
+	meetingRepo     repositories.MeetingRepository
+	userRepo        repositories.UserRepository
+	seriesRepo      repositories.MeetingSeriesRepository
+	saveRepo        repositories.SaveRepository
+	joinRequestRepo repositories.JoinRequestRepository
+	joinRequests    JoinRequestService
+	waitlist        WaitlistService
+	notifications   NotificationService
+	connections     ConnectionManager
+	eventChan       chan<- models.MeetingEvent
 }
 
-func NewMeetingService(repo repositories.MeetingRepository, ec chan<- models.MeetingEvent) MeetingService {
-	return &meetingService{meetingRepo: repo, eventChan: ec}
+func NewMeetingService(
+	repo repositories.MeetingRepository,
+	ur repositories.UserRepository,
+	msr repositories.MeetingSeriesRepository,
+	sr repositories.SaveRepository,
+	jrr repositories.JoinRequestRepository,
+	jrs JoinRequestService,
+	ws WaitlistService,
+	ns NotificationService,
+	cm ConnectionManager,
+	ec chan<- models.MeetingEvent,
+) MeetingService {
+	return &meetingService{
+		meetingRepo:     repo,
+		userRepo:        ur,
+		seriesRepo:      msr,
+		saveRepo:        sr,
+		joinRequestRepo: jrr,
+		joinRequests:    jrs,
+		waitlist:        ws,
+		notifications:   ns,
+		connections:     cm,
+		eventChan:       ec,
+	}
 }
 
 func (s *meetingService) CreateMeeting(ctx context.Context, hostID string, req models.CreateMeetingRequest) error {
@@ -38,6 +91,16 @@ func (s *meetingService) CreateMeeting(ctx context.Context, hostID string, req m
 		return apperr.BadRequest("invalid ID format", err)
 	}
 
+	host, err := findUser(ctx, s.userRepo, hID)
+	if err != nil {
+		return err
+	}
+
+	joinMode, err := validateNewMeeting(req, host)
+	if err != nil {
+		return err
+	}
+
 	meeting := models.Meeting{
 		Title:           req.Title,
 		Description:     req.Description,
@@ -52,8 +115,13 @@ func (s *meetingService) CreateMeeting(ctx context.Context, hostID string, req m
 		Status:          models.MeetingStatusRecruiting,
 		ParticipantIDs:  []primitive.ObjectID{hID},
 		MaxParticipants: req.MaxParticipants,
+		JoinMode:        joinMode,
 		SaveCount:       0,
 		CreatedAt:       time.Now(),
+		GenderQuota:     req.GenderQuota,
+	}
+	if host.Gender != "" {
+		meeting.ParticipantGenders = []models.ParticipantGender{{UserID: hID, Gender: host.Gender}}
 	}
 
 	err = s.meetingRepo.Create(ctx, &meeting)
@@ -64,7 +132,35 @@ func (s *meetingService) CreateMeeting(ctx context.Context, hostID string, req m
 	return nil
 }
 
-func (s *meetingService) GetNearbyMeetings(ctx context.Context, lon, lat float64, radius float64, days []string) ([]models.Meeting, error) {
+// 모임과 반복 모임을 만들 때 공통으로 확인. 비어 있는 참여 방식은 INSTANT로 채워서 돌려줌
+func validateNewMeeting(req models.CreateMeetingRequest, host *models.User) (string, error) {
+	joinMode := req.JoinMode
+	if joinMode == "" {
+		joinMode = models.JoinModeInstant
+	}
+	if !models.IsValidJoinMode(joinMode) {
+		return "", apperr.BadRequest("joinMode must be INSTANT or APPROVAL", nil)
+	}
+
+	if q := req.GenderQuota; q != nil {
+		if q.Male < 0 || q.Female < 0 || q.Male > req.MaxParticipants || q.Female > req.MaxParticipants {
+			return "", apperr.BadRequest("invalid gender quota", nil)
+		}
+		// 합이 정원보다 적으면 모임이 영영 마감되지 않음
+		if q.Male+q.Female < req.MaxParticipants {
+			return "", apperr.BadRequest("gender quota must add up to at least max participants", nil)
+		}
+		// 방장도 참여자라서 방장 성별의 자리가 있어야 함
+		if limit, ok := q.Limit(host.Gender); !ok || limit == 0 {
+			return "", apperr.BadRequest("gender quota must include a spot for the host", nil)
+		}
+	}
+
+	return joinMode, nil
+}
+
+// eligibleOnly면 나이, 성별 제한이나 강퇴 때문에 참여할 수 없는 모임은 빼고 보여줌
+func (s *meetingService) GetNearbyMeetings(ctx context.Context, userID string, lon, lat float64, radius float64, days []string, eligibleOnly bool) ([]models.Meeting, error) {
 	if radius == 0 {
 		log.Println("Radius not provided, defaulting to 3000 meters")
 		radius = 3000 // 기본 3km
@@ -84,7 +180,18 @@ func (s *meetingService) GetNearbyMeetings(ctx context.Context, lon, lat float64
 		}
 	}
 
-	return s.meetingRepo.FindNearby(ctx, lon, lat, radius, daysInt)
+	var viewer *models.User
+	if eligibleOnly {
+		uID, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return nil, apperr.InternalServerError("invalid user ID in token", err)
+		}
+		if viewer, err = findUser(ctx, s.userRepo, uID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.meetingRepo.FindNearby(ctx, lon, lat, radius, daysInt, viewer)
 }
 
 func (s *meetingService) VerifyParticipation(ctx context.Context, meetingID, userID string) error {
@@ -115,31 +222,56 @@ func (s *meetingService) VerifyParticipation(ctx context.Context, meetingID, use
 	return apperr.Forbidden("you are not a participant of the meeting", nil)
 }
 
-func (s *meetingService) JoinMeeting(ctx context.Context, meetingID, userID string) error {
+// 승인제 모임이면 바로 참여하지 않고 신청만 남김. 이때 만들어진 신청을 돌려줌
+func (s *meetingService) JoinMeeting(ctx context.Context, meetingID, userID, message string) (*models.JoinRequest, error) {
 	mID, err := primitive.ObjectIDFromHex(meetingID)
 	if err != nil {
-		return apperr.BadRequest("invalid meeting ID format", err)
+		return nil, apperr.BadRequest("invalid meeting ID format", err)
 	}
 
 	uID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		return apperr.InternalServerError("invalid user ID in token", err)
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
 	}
 
 	meeting, err := s.meetingRepo.FindByID(ctx, mID)
 	if err != nil {
-		return apperr.InternalServerError("failed to fetch meeting", err)
+		return nil, apperr.InternalServerError("failed to fetch meeting", err)
 	}
 	if meeting == nil {
-		return apperr.NotFound("meeting not found", nil)
+		return nil, apperr.NotFound("meeting not found", nil)
+	}
+
+	user, err := findUser(ctx, s.userRepo, uID)
+	if err != nil {
+		return nil, err
+	}
+	if reason := eligibilityReason(meeting, user); reason != "" {
+		return nil, joinBlockedError(reason)
+	}
+
+	if meeting.EffectiveJoinMode() == models.JoinModeApproval {
+		if containsID(meeting.ParticipantIDs, uID) {
+			return nil, apperr.BadRequest("already joined the meeting", nil)
+		}
+		if reason := joinBlockedReason(meeting, user); reason != "" {
+			return nil, joinBlockedError(reason)
+		}
+		return s.joinRequests.Submit(ctx, meeting, uID, message)
 	}
 
-	success, err := s.meetingRepo.AddParticipant(ctx, mID, uID, meeting.MaxParticipants)
+	success, err := s.meetingRepo.AddParticipant(ctx, mID, uID, user.Gender)
 	if err != nil {
-		return apperr.InternalServerError("failed to add participant", err)
+		return nil, apperr.InternalServerError("failed to add participant", err)
 	}
 	if !success {
-		return apperr.BadRequest("failed to join the meeting", errors.New("meeting may be full or user already joined"))
+		// 어떤 조건에 걸렸는지 최신 상태로 다시 확인
+		if latest, err := s.meetingRepo.FindByID(ctx, mID); err == nil && latest != nil && !containsID(latest.ParticipantIDs, uID) {
+			if reason := joinBlockedReason(latest, user); reason != "" {
+				return nil, joinBlockedError(reason)
+			}
+		}
+		return nil, apperr.BadRequest("failed to join the meeting", errors.New("meeting may be full or user already joined"))
 	}
 
 	s.eventChan <- models.MeetingEvent{
@@ -148,7 +280,7 @@ func (s *meetingService) JoinMeeting(ctx context.Context, meetingID, userID stri
 		UserID:    userID,
 	}
 
-	return nil
+	return nil, nil
 }
 
 func (s *meetingService) LeaveMeeting(ctx context.Context, meetingID, userID string) error {
@@ -170,12 +302,12 @@ func (s *meetingService) LeaveMeeting(ctx context.Context, meetingID, userID str
 		return apperr.NotFound("meeting not found", nil)
 	}
 
-	// 방장은 못나감
+	// 방장은 다른 참여자에게 넘긴 뒤에 나갈 수 있음
 	if meeting.HostID == uID {
-		return apperr.BadRequest("host cannot leave the meeting", nil)
+		return apperr.BadRequest("host must transfer the meeting before leaving", nil)
 	}
 
-	success, err := s.meetingRepo.RemoveParticipant(ctx, mID, uID, meeting.MaxParticipants)
+	success, err := s.meetingRepo.RemoveParticipant(ctx, mID, uID)
 	if err != nil {
 		return apperr.InternalServerError("failed to remove participant", err)
 	}
@@ -183,5 +315,795 @@ func (s *meetingService) LeaveMeeting(ctx context.Context, meetingID, userID str
 		return apperr.BadRequest("failed to leave the meeting", errors.New("user may not be a participant"))
 	}
 
+	s.promoteWaitlist(ctx, mID)
+	return nil
+}
+
+func (s *meetingService) GetMeetingDetail(ctx context.Context, meetingID, userID string) (*models.MeetingDetail, error) {
+	mID, err := primitive.ObjectIDFromHex(meetingID)
+	if err != nil {
+		return nil, apperr.BadRequest("invalid meeting ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	detail, err := s.meetingRepo.FindDetailByID(ctx, mID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch meeting", err)
+	}
+	if detail == nil {
+		return nil, apperr.NotFound("meeting not found", nil)
+	}
+
+	// $lookup 결과를 참여 순서대로 다시 정렬. 삭제된 유저는 빠짐
+	byID := make(map[primitive.ObjectID]models.UserSummary, len(detail.Participants))
+	for _, p := range detail.Participants {
+		byID[p.ID] = p
+	}
+	participants := make([]models.UserSummary, 0, len(detail.Participants))
+	for _, pID := range detail.ParticipantIDs {
+		if p, ok := byID[pID]; ok {
+			participants = append(participants, p)
+		}
+	}
+	detail.Participants = participants
+
+	if host, ok := byID[detail.HostID]; ok {
+		detail.Host = &host
+	}
+
+	saved, err := s.saveRepo.Exists(ctx, uID, mID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch save status", err)
+	}
+
+	viewer, err := findUser(ctx, s.userRepo, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail.Viewer = models.MeetingViewer{
+		IsHost:   detail.HostID == uID,
+		IsCoHost: detail.IsCoHost(uID),
+		Joined:   containsID(detail.ParticipantIDs, uID),
+		Saved:    saved,
+	}
+	if !detail.Viewer.Joined {
+		detail.Viewer.JoinBlockedReason = joinBlockedReason(&detail.Meeting, viewer)
+		detail.Viewer.CanJoin = detail.Viewer.JoinBlockedReason == ""
+
+		pending, err := s.joinRequestRepo.FindPending(ctx, mID, uID)
+		if err != nil {
+			return nil, apperr.InternalServerError("failed to fetch join request", err)
+		}
+		detail.Viewer.JoinPending = pending != nil
+
+		if detail.WaitlistCount > 0 {
+			position, err := s.waitlist.FindPosition(ctx, mID, uID)
+			if err != nil {
+				return nil, err
+			}
+			if position != nil {
+				detail.Viewer.WaitlistPosition = position.Position
+			}
+		}
+	}
+
+	return detail, nil
+}
+
+// 모임 상태와 상관없이 이 유저가 참여할 자격이 있는지
+func eligibilityReason(meeting *models.Meeting, user *models.User) string {
+	switch {
+	case meeting.IsBanned(user.ID):
+		return models.JoinBlockedBanned
+	case !meeting.AllowsAge(user.Age):
+		return models.JoinBlockedAge
+	case !meeting.AllowsGender(user.Gender):
+		return models.JoinBlockedGender
+	}
+	return ""
+}
+
+// AddParticipant의 조건과 맞춰야 함. 빈자리를 먼저 가져갈 대기자가 있으면 마감으로 봄
+func joinBlockedReason(meeting *models.Meeting, user *models.User) string {
+	if reason := eligibilityReason(meeting, user); reason != "" {
+		return reason
+	}
+
+	// 끝난 모임이 정원까지 차 있어도 마감이 아니라 종료로 보여야 대기열에 들어오지 않음
+	switch {
+	case !meeting.IsActive():
+		return models.JoinBlockedClosed
+	case meeting.Status == models.MeetingStatusFull || len(meeting.ParticipantIDs) >= meeting.MaxParticipants || meeting.HasWaitersAhead(user.Gender):
+		return models.JoinBlockedFull
+	case meeting.IsGenderFull(user.Gender):
+		return models.JoinBlockedGenderFull
+	}
+	return ""
+}
+
+// 클라이언트가 code로 이유를 구분할 수 있게 함
+func joinBlockedError(reason string) error {
+	switch reason {
+	case models.JoinBlockedBanned:
+		return apperr.Forbidden("you are banned from this meeting", nil).WithCode(apperr.CodeBannedFromMeeting)
+	case models.JoinBlockedAge:
+		return apperr.Forbidden("age is outside the meeting's age range", nil).WithCode(apperr.CodeAgeNotAllowed)
+	case models.JoinBlockedGender:
+		return apperr.Forbidden("meeting is not open to this gender", nil).WithCode(apperr.CodeGenderNotAllowed)
+	case models.JoinBlockedFull:
+		return apperr.BadRequest("meeting is full", nil).WithCode(apperr.CodeMeetingFull)
+	case models.JoinBlockedGenderFull:
+		return apperr.BadRequest("no spots left for this gender", nil).WithCode(apperr.CodeGenderQuotaFull)
+	}
+	return apperr.BadRequest("meeting is not accepting participants", nil)
+}
+
+func containsID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// 시간, 장소, 정원, 나이 제한이 바뀌면 채팅방에 알림
+func (s *meetingService) UpdateMeeting(ctx context.Context, meetingID, userID string, req models.UpdateMeetingRequest) (*models.Meeting, error) {
+	mID, err := primitive.ObjectIDFromHex(meetingID)
+	if err != nil {
+		return nil, apperr.BadRequest("invalid meeting ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	meeting, err := s.meetingRepo.FindByID(ctx, mID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch meeting", err)
+	}
+	if meeting == nil {
+		return nil, apperr.NotFound("meeting not found", nil)
+	}
+	if !meeting.CanManage(uID) {
+		return nil, apperr.Forbidden("only the host or co-hosts can edit the meeting", nil)
+	}
+	if meeting.Status != models.MeetingStatusRecruiting && meeting.Status != models.MeetingStatusFull {
+		return nil, apperr.BadRequest("meeting can no longer be edited", nil)
+	}
+
+	following := false
+	switch req.Scope {
+	case "", models.EditScopeThis:
+	case models.EditScopeFollowing:
+		if meeting.SeriesID == nil {
+			return nil, apperr.BadRequest("scope FOLLOWING is only available for series occurrences", nil)
+		}
+		following = true
+	default:
+		return nil, apperr.BadRequest("scope must be THIS or FOLLOWING", nil)
+	}
+
+	updates := bson.M{}
+	var changes []string
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, apperr.BadRequest("title cannot be empty", nil)
+		}
+		updates["title"] = title
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Category != nil {
+		if *req.Category == "" {
+			return nil, apperr.BadRequest("category cannot be empty", nil)
+		}
+		updates["category"] = *req.Category
+	}
+	if req.ImageURL != nil {
+		updates["image_url"] = *req.ImageURL
+	}
+
+	placeChanged := false
+	if req.PlaceName != nil {
+		placeName := strings.TrimSpace(*req.PlaceName)
+		if placeName == "" {
+			return nil, apperr.BadRequest("place name cannot be empty", nil)
+		}
+		updates["place_name"] = placeName
+		placeChanged = placeChanged || placeName != meeting.PlaceName
+	}
+	if req.Location != nil {
+		if err := validateLocation(*req.Location); err != nil {
+			return nil, err
+		}
+		updates["location"] = *req.Location
+		placeChanged = placeChanged || !sameLocation(*req.Location, meeting.Location)
+	}
+	if placeChanged {
+		changes = append(changes, "장소")
+	}
+
+	if req.MeetingTime != nil {
+		if !req.MeetingTime.After(time.Now()) {
+			return nil, apperr.BadRequest("meeting time must be in the future", nil)
+		}
+		updates["meeting_time"] = *req.MeetingTime
+		updates["day_of_week"] = int(req.MeetingTime.In(meetingTimeZone).Weekday())
+		if !req.MeetingTime.Equal(meeting.MeetingTime) {
+			changes = append(changes, "시간")
+		}
+	}
+
+	if req.JoinMode != nil {
+		if !models.IsValidJoinMode(*req.JoinMode) {
+			return nil, apperr.BadRequest("joinMode must be INSTANT or APPROVAL", nil)
+		}
+		updates["join_mode"] = *req.JoinMode
+	}
+
+	if req.AgeRange != nil {
+		ageRange := *req.AgeRange
+		// 상한이 0이면 제한 없음
+		if ageRange[0] < 0 || ageRange[1] < 0 || (ageRange[1] > 0 && ageRange[0] > ageRange[1]) {
+			return nil, apperr.BadRequest("invalid age range", nil)
+		}
+		updates["age_range"] = ageRange
+		if ageRange != meeting.AgeRange {
+			changes = append(changes, "나이 제한")
+		}
+	}
+
+	maxParticipants := 0
+	if req.MaxParticipants != nil {
+		maxParticipants = *req.MaxParticipants
+		if maxParticipants < len(meeting.ParticipantIDs) {
+			return nil, apperr.BadRequest("max participants cannot be less than the current participant count", nil)
+		}
+		if q := meeting.GenderQuota; q != nil && maxParticipants > q.Male+q.Female {
+			return nil, apperr.BadRequest("max participants cannot exceed the gender quota total", nil)
+		}
+		updates["max_participants"] = maxParticipants
+		if maxParticipants != meeting.MaxParticipants {
+			changes = append(changes, "정원")
+		}
+	}
+
+	if len(updates) == 0 {
+		return meeting, nil
+	}
+
+	// 시리즈 틀은 검사만 먼저 하고, 이 회차 수정이 성공한 뒤에 바꿈
+	var series *models.MeetingSeries
+	var template bson.M
+	var shift time.Duration
+	if following {
+		if req.MeetingTime != nil {
+			shift = req.MeetingTime.Sub(meeting.MeetingTime)
+		}
+		if series, template, err = s.prepareSeriesTemplate(ctx, meeting, uID, updates, maxParticipants, shift); err != nil {
+			return nil, err
+		}
+	}
+
+	success, err := s.meetingRepo.Update(ctx, mID, uID, updates, maxParticipants)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to update meeting", err)
+	}
+	if !success {
+		// 조회 이후에 참여자가 늘었거나 모임 상태가 바뀐 경우
+		return nil, apperr.Conflict("meeting has changed, please try again", nil)
+	}
+
+	if series != nil {
+		if series, err = s.applySeriesTemplate(ctx, series, template, shift); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.meetingRepo.FindByID(ctx, mID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch meeting", err)
+	}
+	if updated == nil {
+		return nil, apperr.NotFound("meeting not found", nil)
+	}
+
+	if len(changes) > 0 {
+		s.eventChan <- models.MeetingEvent{
+			Type:      models.EventUpdateMeeting,
+			MeetingID: meetingID,
+			UserID:    userID,
+			Changes:   changes,
+		}
+	}
+
+	s.clearWaitlistIfApproval(ctx, meeting, updates)
+
+	if series != nil {
+		s.updateFollowingOccurrences(ctx, meeting, series, uID, updates, changes, maxParticipants, shift)
+	}
+
+	// 정원이 늘었으면 대기자를 올림
+	if updated.MaxParticipants > meeting.MaxParticipants {
+		s.promoteWaitlist(ctx, mID)
+		if updated, err = s.meetingRepo.FindByID(ctx, mID); err != nil {
+			return nil, apperr.InternalServerError("failed to fetch meeting", err)
+		}
+		if updated == nil {
+			return nil, apperr.NotFound("meeting not found", nil)
+		}
+	}
+
+	return updated, nil
+}
+
+// 모집 중이거나 마감된 모임만 취소 가능. 시작한 모임은 취소할 수 없음
+func (s *meetingService) CancelMeeting(ctx context.Context, meetingID, userID, reason string) error {
+	mID, err := primitive.ObjectIDFromHex(meetingID)
+	if err != nil {
+		return apperr.BadRequest("invalid meeting ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > 200 {
+		return apperr.BadRequest("reason must be at most 200 characters", nil)
+	}
+
+	meeting, err := s.meetingRepo.FindByID(ctx, mID)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch meeting", err)
+	}
+	if meeting == nil {
+		return apperr.NotFound("meeting not found", nil)
+	}
+	if !meeting.CanManage(uID) {
+		return apperr.Forbidden("only the host or co-hosts can cancel the meeting", nil)
+	}
+
+	cancelled, err := s.cancel(ctx, meeting, userID, reason)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return apperr.BadRequest("meeting can no longer be cancelled", nil)
+	}
+	return nil
+}
+
+// 이미 끝났거나 취소된 모임이면 아무것도 하지 않음
+func (s *meetingService) CancelForDeletedHost(ctx context.Context, meeting *models.Meeting) error {
+	// 이벤트가 처리될 때는 계정이 이미 지워졌을 수 있어서 취소한 사람은 비워 둠
+	_, err := s.cancel(ctx, meeting, "", models.CancelReasonHostDeleted)
+	return err
+}
+
+// 상태를 바꾼 쪽만 채팅방 안내, 대기열/신청 정리, 알림을 보냄. 취소됐는지 돌려줌
+func (s *meetingService) cancel(ctx context.Context, meeting *models.Meeting, cancellerID, reason string) (bool, error) {
+	activeStatuses := []string{models.MeetingStatusRecruiting, models.MeetingStatusFull}
+	success, err := s.meetingRepo.UpdateStatus(ctx, meeting.ID, activeStatuses, models.MeetingStatusCancelled)
+	if err != nil {
+		return false, apperr.InternalServerError("failed to cancel meeting", err)
+	}
+	if !success {
+		return false, nil
+	}
+
+	meetingID := meeting.ID.Hex()
+	s.eventChan <- models.MeetingEvent{
+		Type:      models.EventCancelMeeting,
+		MeetingID: meetingID,
+		UserID:    cancellerID,
+		Reason:    reason,
+	}
+
+	// 취소는 이미 끝났으니 정리와 알림 실패는 기록만
+	waitingIDs, err := s.waitlist.Clear(ctx, meeting.ID)
+	if err != nil {
+		log.Printf("Failed to clear waitlist of cancelled meeting %s: %v", meetingID, err)
+	}
+	requesterIDs, err := s.joinRequestRepo.CancelAllPending(ctx, meeting.ID, time.Now())
+	if err != nil {
+		log.Printf("Failed to cancel join requests of cancelled meeting %s: %v", meetingID, err)
+	}
+
+	if err := s.notifications.NotifyMeetingCancelled(ctx, meeting, reason, append(waitingIDs, requesterIDs...)); err != nil {
+		log.Printf("Failed to notify cancellation of meeting %s: %v", meetingID, err)
+	}
+
+	return true, nil
+}
+
+// 모임 시간이 된 모임은 진행 중으로, 진행 시간이 지난 모임은 종료로
+// 상태를 조건부로 바꾸므로 같은 모임을 두 번 처리해도 이벤트는 한 번만 나감
+func (s *meetingService) AdvanceLifecycle(ctx context.Context, now time.Time) (started, finished int, err error) {
+	activeStatuses := []string{models.MeetingStatusRecruiting, models.MeetingStatusFull}
+
+	// 시작 시각을 놓친 채로 종료 시각까지 지난 모임도 먼저 진행 중을 거쳐서 이벤트 순서를 지킴
+	due, err := s.meetingRepo.FindByStatusBefore(ctx, activeStatuses, now, lifecycleBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, meeting := range due {
+		ok, err := s.meetingRepo.UpdateStatus(ctx, meeting.ID, activeStatuses, models.MeetingStatusOngoing)
+		if err != nil {
+			return started, finished, err
+		}
+		if ok {
+			started++
+			s.eventChan <- models.MeetingEvent{Type: models.EventStartMeeting, MeetingID: meeting.ID.Hex()}
+		}
+	}
+
+	ongoing := []string{models.MeetingStatusOngoing}
+	due, err = s.meetingRepo.FindByStatusBefore(ctx, ongoing, now.Add(-config.AppConfig.MeetingDuration), lifecycleBatchSize)
+	if err != nil {
+		return started, finished, err
+	}
+	for _, meeting := range due {
+		ok, err := s.meetingRepo.UpdateStatus(ctx, meeting.ID, ongoing, models.MeetingStatusFinished)
+		if err != nil {
+			return started, finished, err
+		}
+		if ok {
+			finished++
+			s.eventChan <- models.MeetingEvent{Type: models.EventFinishMeeting, MeetingID: meeting.ID.Hex()}
+		}
+	}
+
+	return started, finished, nil
+}
+
+// 방장만 가능. 기존 방장은 일반 참여자로 남음
+func (s *meetingService) TransferHost(ctx context.Context, meetingID, userID, newHostID string) error {
+	meeting, uID, targetID, err := s.loadForMemberChange(ctx, meetingID, userID, newHostID)
+	if err != nil {
+		return err
+	}
+	if meeting.HostID != uID {
+		return apperr.Forbidden("only the host can transfer the meeting", nil)
+	}
+	if targetID == uID {
+		return apperr.BadRequest("you are already the host", nil)
+	}
+	if !containsID(meeting.ParticipantIDs, targetID) {
+		return apperr.BadRequest("new host must be a participant of the meeting", nil)
+	}
+
+	success, err := s.meetingRepo.TransferHost(ctx, meeting.ID, uID, targetID)
+	if err != nil {
+		return apperr.InternalServerError("failed to transfer host", err)
+	}
+	if !success {
+		return apperr.Conflict("meeting has changed, please try again", nil)
+	}
+
+	s.eventChan <- models.MeetingEvent{
+		Type:      models.EventTransferHost,
+		MeetingID: meetingID,
+		UserID:    newHostID,
+	}
 	return nil
 }
+
+func (s *meetingService) AddCoHost(ctx context.Context, meetingID, userID, targetID string) error {
+	meeting, uID, tID, err := s.loadForMemberChange(ctx, meetingID, userID, targetID)
+	if err != nil {
+		return err
+	}
+	if meeting.HostID != uID {
+		return apperr.Forbidden("only the host can add co-hosts", nil)
+	}
+	if tID == uID {
+		return apperr.BadRequest("host cannot be a co-host", nil)
+	}
+	if !containsID(meeting.ParticipantIDs, tID) {
+		return apperr.BadRequest("co-host must be a participant of the meeting", nil)
+	}
+	if meeting.IsCoHost(tID) {
+		return apperr.Conflict("user is already a co-host", nil)
+	}
+
+	success, err := s.meetingRepo.AddCoHost(ctx, meeting.ID, uID, tID)
+	if err != nil {
+		return apperr.InternalServerError("failed to add co-host", err)
+	}
+	if !success {
+		return apperr.Conflict("meeting has changed, please try again", nil)
+	}
+
+	s.eventChan <- models.MeetingEvent{
+		Type:      models.EventAddCoHost,
+		MeetingID: meetingID,
+		UserID:    targetID,
+	}
+	return nil
+}
+
+// 방장이 해제하거나 공동 방장 본인이 내려놓음
+func (s *meetingService) RemoveCoHost(ctx context.Context, meetingID, userID, targetID string) error {
+	meeting, uID, tID, err := s.loadForMemberChange(ctx, meetingID, userID, targetID)
+	if err != nil {
+		return err
+	}
+	if meeting.HostID != uID && uID != tID {
+		return apperr.Forbidden("only the host can remove other co-hosts", nil)
+	}
+	if !meeting.IsCoHost(tID) {
+		return apperr.NotFound("user is not a co-host", nil)
+	}
+
+	success, err := s.meetingRepo.RemoveCoHost(ctx, meeting.ID, tID)
+	if err != nil {
+		return apperr.InternalServerError("failed to remove co-host", err)
+	}
+	if !success {
+		return apperr.NotFound("user is not a co-host", nil)
+	}
+
+	s.eventChan <- models.MeetingEvent{
+		Type:      models.EventRemoveCoHost,
+		MeetingID: meetingID,
+		UserID:    targetID,
+	}
+	return nil
+}
+
+// 방장은 누구든, 공동 방장은 일반 참여자만 내보낼 수 있음
+// 해당 방의 웹소켓 연결도 바로 끊어서 참여 확인을 다시 거치게 함
+func (s *meetingService) KickParticipant(ctx context.Context, meetingID, userID, targetID string, ban bool) error {
+	meeting, uID, tID, err := s.loadForMemberChange(ctx, meetingID, userID, targetID)
+	if err != nil {
+		return err
+	}
+	if !meeting.CanManage(uID) {
+		return apperr.Forbidden("only the host or co-hosts can kick participants", nil)
+	}
+	if tID == uID {
+		return apperr.BadRequest("you cannot kick yourself", nil)
+	}
+	if meeting.HostID == tID || (meeting.HostID != uID && meeting.IsCoHost(tID)) {
+		return apperr.Forbidden("you cannot kick this participant", nil)
+	}
+	if !containsID(meeting.ParticipantIDs, tID) {
+		return apperr.NotFound("user is not a participant of the meeting", nil)
+	}
+
+	// 내보내기 전에 막아야 그 사이에 다시 들어오지 못함
+	if ban {
+		if err := s.meetingRepo.Ban(ctx, meeting.ID, tID); err != nil {
+			return apperr.InternalServerError("failed to ban participant", err)
+		}
+		// 반복 모임이면 고정 멤버에서도 빼고 다음 회차부터도 막음
+		if meeting.SeriesID != nil {
+			if err := s.seriesRepo.Ban(ctx, *meeting.SeriesID, tID); err != nil {
+				return apperr.InternalServerError("failed to ban participant from series", err)
+			}
+		}
+	}
+
+	success, err := s.meetingRepo.RemoveParticipant(ctx, meeting.ID, tID)
+	if err != nil {
+		return apperr.InternalServerError("failed to remove participant", err)
+	}
+	if !success {
+		return apperr.NotFound("user is not a participant of the meeting", nil)
+	}
+
+	s.connections.DisconnectUserFromMeeting(targetID, meetingID)
+
+	s.eventChan <- models.MeetingEvent{
+		Type:      models.EventKickMember,
+		MeetingID: meetingID,
+		UserID:    targetID,
+	}
+
+	s.promoteWaitlist(ctx, meeting.ID)
+	return nil
+}
+
+// FOLLOWING 수정을 시리즈 틀에 반영할 수 있는지 확인하고 바꿀 필드를 만듦. 아직 쓰지는 않음
+func (s *meetingService) prepareSeriesTemplate(ctx context.Context, meeting *models.Meeting, editorID primitive.ObjectID, updates bson.M, maxParticipants int, shift time.Duration) (*models.MeetingSeries, bson.M, error) {
+	series, err := s.seriesRepo.FindByID(ctx, *meeting.SeriesID)
+	if err != nil {
+		return nil, nil, apperr.InternalServerError("failed to fetch meeting series", err)
+	}
+	if series == nil {
+		return nil, nil, apperr.NotFound("meeting series not found", nil)
+	}
+	if series.HostID != editorID {
+		return nil, nil, apperr.Forbidden("only the series host can edit following occurrences", nil)
+	}
+	if maxParticipants != 0 && maxParticipants < len(series.MemberIDs)+1 {
+		return nil, nil, apperr.BadRequest("max participants cannot be less than the series member count", nil)
+	}
+	// 주기 이상 옮기면 회차 순서가 뒤섞임
+	interval := series.Recurrence.Interval()
+	if shift >= interval || shift <= -interval {
+		return nil, nil, apperr.BadRequest("meeting time must move by less than the recurrence interval", nil)
+	}
+
+	template := bson.M{}
+	for k, v := range updates {
+		if k != "meeting_time" && k != "day_of_week" {
+			template[k] = v
+		}
+	}
+	return series, template, nil
+}
+
+// 그 사이에 새 회차가 만들어졌으면 시리즈를 다시 읽고 시각을 새로 계산해서 다시 시도함.
+// 옛 틀로 만들어진 회차는 뒤이은 updateFollowingOccurrences가 고침
+func (s *meetingService) applySeriesTemplate(ctx context.Context, series *models.MeetingSeries, template bson.M, shift time.Duration) (*models.MeetingSeries, error) {
+	for attempt := 0; attempt < seriesUpdateRetries; attempt++ {
+		if series.Status != models.SeriesStatusActive {
+			return series, nil
+		}
+
+		updates := bson.M{}
+		for k, v := range template {
+			updates[k] = v
+		}
+		if shift != 0 {
+			updates["start_time"] = series.StartTime.Add(shift)
+			updates["next_occurrence"] = series.NextOccurrence.Add(shift)
+		}
+		if len(updates) == 0 {
+			return series, nil
+		}
+
+		success, err := s.seriesRepo.Update(ctx, series.ID, series.Materialized, updates)
+		if err != nil {
+			return nil, apperr.InternalServerError("failed to update meeting series", err)
+		}
+		if success {
+			return series, nil
+		}
+
+		latest, err := s.seriesRepo.FindByID(ctx, series.ID)
+		if err != nil {
+			return nil, apperr.InternalServerError("failed to fetch meeting series", err)
+		}
+		if latest == nil {
+			return nil, apperr.NotFound("meeting series not found", nil)
+		}
+		series = latest
+	}
+	return nil, apperr.Conflict("meeting series has changed, please try again", nil)
+}
+
+// 이미 만들어진 이후 회차에 같은 수정을 적용. 회차마다 참여자 수가 달라서 안 되는 회차는 건너뜀
+func (s *meetingService) updateFollowingOccurrences(ctx context.Context, meeting *models.Meeting, series *models.MeetingSeries, editorID primitive.ObjectID, updates bson.M, changes []string, maxParticipants int, shift time.Duration) {
+	activeStatuses := []string{models.MeetingStatusRecruiting, models.MeetingStatusFull}
+	occurrences, err := s.meetingRepo.FindBySeries(ctx, series.ID, meeting.Occurrence, activeStatuses)
+	if err != nil {
+		log.Printf("Failed to fetch following occurrences of series %s: %v", series.ID.Hex(), err)
+		return
+	}
+
+	for _, occ := range occurrences {
+		occUpdates := bson.M{}
+		for k, v := range updates {
+			occUpdates[k] = v
+		}
+		if shift != 0 {
+			meetingTime := occ.MeetingTime.Add(shift)
+			occUpdates["meeting_time"] = meetingTime
+			occUpdates["day_of_week"] = int(meetingTime.In(meetingTimeZone).Weekday())
+		}
+
+		success, err := s.meetingRepo.Update(ctx, occ.ID, editorID, occUpdates, maxParticipants)
+		if err != nil || !success {
+			log.Printf("Skipped series edit for meeting %s: %v", occ.ID.Hex(), err)
+			continue
+		}
+
+		s.clearWaitlistIfApproval(ctx, &occ, updates)
+
+		if len(changes) > 0 {
+			s.eventChan <- models.MeetingEvent{
+				Type:      models.EventUpdateMeeting,
+				MeetingID: occ.ID.Hex(),
+				UserID:    editorID.Hex(),
+				Changes:   changes,
+			}
+		}
+		if maxParticipants > occ.MaxParticipants {
+			s.promoteWaitlist(ctx, occ.ID)
+		}
+	}
+}
+
+// 바로 참여에서 승인제로 바뀌면 대기자는 신청을 다시 하게 함. 수정은 이미 끝났으니 실패는 기록만
+func (s *meetingService) clearWaitlistIfApproval(ctx context.Context, before *models.Meeting, updates bson.M) {
+	if mode, _ := updates["join_mode"].(string); mode != models.JoinModeApproval || before.EffectiveJoinMode() != models.JoinModeInstant {
+		return
+	}
+
+	userIDs, err := s.waitlist.Clear(ctx, before.ID)
+	if err != nil {
+		log.Printf("Failed to clear waitlist of meeting %s: %v", before.ID.Hex(), err)
+		return
+	}
+	if err := s.notifications.NotifyWaitlistCleared(ctx, before, userIDs); err != nil {
+		log.Printf("Failed to notify waitlist clear of meeting %s: %v", before.ID.Hex(), err)
+	}
+}
+
+// 참여 자격을 확인할 때 쓰는 유저 조회. 모임 관련 서비스들이 같이 씀
+func findUser(ctx context.Context, users repositories.UserRepository, userID primitive.ObjectID) (*models.User, error) {
+	user, err := users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by ID", err)
+	}
+	if user == nil {
+		return nil, apperr.NotFound("user not found", nil)
+	}
+	return user, nil
+}
+
+// 자리가 난 뒤에 호출. 실패해도 원래 요청은 성공시키고, 다음 빈자리 때 다시 시도됨
+func (s *meetingService) promoteWaitlist(ctx context.Context, meetingID primitive.ObjectID) {
+	if err := s.waitlist.Promote(ctx, meetingID); err != nil {
+		log.Printf("Failed to promote waitlist of meeting %s: %v", meetingID.Hex(), err)
+	}
+}
+
+// 역할 변경이나 강퇴는 끝나거나 취소된 모임에서는 의미가 없음
+func (s *meetingService) loadForMemberChange(ctx context.Context, meetingID, userID, targetID string) (*models.Meeting, primitive.ObjectID, primitive.ObjectID, error) {
+	var zero primitive.ObjectID
+
+	mID, err := primitive.ObjectIDFromHex(meetingID)
+	if err != nil {
+		return nil, zero, zero, apperr.BadRequest("invalid meeting ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, zero, zero, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	tID, err := primitive.ObjectIDFromHex(targetID)
+	if err != nil {
+		return nil, zero, zero, apperr.BadRequest("invalid user ID format", err)
+	}
+
+	meeting, err := s.meetingRepo.FindByID(ctx, mID)
+	if err != nil {
+		return nil, zero, zero, apperr.InternalServerError("failed to fetch meeting", err)
+	}
+	if meeting == nil {
+		return nil, zero, zero, apperr.NotFound("meeting not found", nil)
+	}
+	if meeting.Status == models.MeetingStatusCancelled || meeting.Status == models.MeetingStatusFinished {
+		return nil, zero, zero, apperr.BadRequest("meeting has already ended", nil)
+	}
+
+	return meeting, uID, tID, nil
+}
+
+func sameLocation(a, b models.Location) bool {
+	if a.Type != b.Type || len(a.Coordinates) != len(b.Coordinates) {
+		return false
+	}
+	for i := range a.Coordinates {
+		if a.Coordinates[i] != b.Coordinates[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,268 @@
+// api/services/meeting_service_test.go
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUpdateSeriesTemplate(t *testing.T) {
+	hostID := primitive.NewObjectID()
+	start := time.Date(2026, 3, 2, 19, 0, 0, 0, time.UTC)
+	next := start.AddDate(0, 0, 21)
+	newTime := start.AddDate(0, 0, 14).Add(2 * time.Hour)
+
+	tests := []struct {
+		name            string
+		frequency       string
+		status          string
+		editorID        primitive.ObjectID
+		maxParticipants int
+		shift           time.Duration
+		concurrent      bool
+		wantStatus      int
+		wantUpdate      bool
+	}{
+		{name: "later on the same day", frequency: models.RecurrenceWeekly, shift: 2 * time.Hour, wantUpdate: true},
+		{name: "earlier by almost a week", frequency: models.RecurrenceWeekly, shift: -6 * 24 * time.Hour, wantUpdate: true},
+		{name: "biweekly allows ten days", frequency: models.RecurrenceBiweekly, shift: 10 * 24 * time.Hour, wantUpdate: true},
+		{name: "full interval", frequency: models.RecurrenceWeekly, shift: 7 * 24 * time.Hour, wantStatus: http.StatusBadRequest},
+		{name: "full interval back", frequency: models.RecurrenceWeekly, shift: -7 * 24 * time.Hour, wantStatus: http.StatusBadRequest},
+		{name: "co-host cannot edit series", frequency: models.RecurrenceWeekly, editorID: primitive.NewObjectID(), wantStatus: http.StatusForbidden},
+		{name: "below member count", frequency: models.RecurrenceWeekly, maxParticipants: 2, wantStatus: http.StatusBadRequest},
+		{name: "keeps changing meanwhile", frequency: models.RecurrenceWeekly, shift: time.Hour, concurrent: true, wantStatus: http.StatusConflict},
+		{name: "ended series keeps template", frequency: models.RecurrenceWeekly, status: models.SeriesStatusEnded, shift: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			if status == "" {
+				status = models.SeriesStatusActive
+			}
+			editorID := tt.editorID
+			if editorID.IsZero() {
+				editorID = hostID
+			}
+
+			series := &models.MeetingSeries{
+				ID:             primitive.NewObjectID(),
+				HostID:         hostID,
+				MemberIDs:      []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()},
+				Recurrence:     models.RecurrenceRule{Frequency: tt.frequency, Count: 10},
+				StartTime:      start,
+				NextOccurrence: next,
+				Materialized:   3,
+				Status:         status,
+			}
+			repo := &memSeriesRepo{series: series, concurrent: tt.concurrent}
+			s := &meetingService{seriesRepo: repo}
+
+			meeting := &models.Meeting{SeriesID: &series.ID}
+			updates := bson.M{
+				"title":        "새 제목",
+				"meeting_time": newTime,
+				"day_of_week":  int(newTime.Weekday()),
+			}
+
+			loaded, template, err := s.prepareSeriesTemplate(context.Background(), meeting, editorID, updates, tt.maxParticipants, tt.shift)
+			if err == nil {
+				_, err = s.applySeriesTemplate(context.Background(), loaded, template, tt.shift)
+			}
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.wantStatus, err)
+			}
+			if !tt.wantUpdate {
+				if repo.updated != nil {
+					t.Errorf("template updated: %v", repo.updated)
+				}
+				return
+			}
+
+			// 회차별 시각은 틀에 들어가면 안 되고, 시작 시각과 다음 회차만 같은 만큼 옮김
+			if _, ok := repo.updated["meeting_time"]; ok {
+				t.Error("meeting_time leaked into template")
+			}
+			if _, ok := repo.updated["day_of_week"]; ok {
+				t.Error("day_of_week leaked into template")
+			}
+			if repo.updated["title"] != "새 제목" {
+				t.Errorf("title = %v", repo.updated["title"])
+			}
+			if got := repo.updated["start_time"]; got != start.Add(tt.shift) {
+				t.Errorf("start_time = %v, want %v", got, start.Add(tt.shift))
+			}
+			if got := repo.updated["next_occurrence"]; got != next.Add(tt.shift) {
+				t.Errorf("next_occurrence = %v, want %v", got, next.Add(tt.shift))
+			}
+		})
+	}
+}
+
+func TestUpdateSeriesTemplateWithoutShift(t *testing.T) {
+	hostID := primitive.NewObjectID()
+	series := &models.MeetingSeries{
+		ID:         primitive.NewObjectID(),
+		HostID:     hostID,
+		Recurrence: models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 10},
+		StartTime:  time.Date(2026, 3, 2, 19, 0, 0, 0, time.UTC),
+		Status:     models.SeriesStatusActive,
+	}
+	repo := &memSeriesRepo{series: series}
+	s := &meetingService{seriesRepo: repo}
+
+	loaded, template, err := s.prepareSeriesTemplate(context.Background(), &models.Meeting{SeriesID: &series.ID}, hostID, bson.M{"title": "새 제목"}, 0, 0)
+	if err == nil {
+		_, err = s.applySeriesTemplate(context.Background(), loaded, template, 0)
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := repo.updated["start_time"]; ok {
+		t.Error("start_time changed without a time shift")
+	}
+	if _, ok := repo.updated["next_occurrence"]; ok {
+		t.Error("next_occurrence changed without a time shift")
+	}
+}
+
+func TestApplySeriesTemplateAfterMaterialize(t *testing.T) {
+	start := time.Date(2026, 3, 2, 19, 0, 0, 0, time.UTC)
+	series := &models.MeetingSeries{
+		ID:             primitive.NewObjectID(),
+		Recurrence:     models.RecurrenceRule{Frequency: models.RecurrenceWeekly, Count: 10},
+		StartTime:      start,
+		NextOccurrence: start.AddDate(0, 0, 14),
+		Materialized:   2,
+		Status:         models.SeriesStatusActive,
+	}
+	// 틀을 검사한 뒤 회차 하나가 더 만들어진 상태
+	stale := *series
+	series.Materialized = 3
+	series.NextOccurrence = start.AddDate(0, 0, 21)
+	repo := &memSeriesRepo{series: series}
+	s := &meetingService{seriesRepo: repo}
+
+	_, err := s.applySeriesTemplate(context.Background(), &stale, bson.M{"title": "새 제목"}, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := repo.updated["next_occurrence"], start.AddDate(0, 0, 21).Add(time.Hour); got != want {
+		t.Errorf("next_occurrence = %v, want %v", got, want)
+	}
+	if repo.updated["title"] != "새 제목" {
+		t.Errorf("title = %v", repo.updated["title"])
+	}
+}
+
+func TestJoinBlockedReason(t *testing.T) {
+	hostID := primitive.NewObjectID()
+	male := &models.User{ID: primitive.NewObjectID(), Gender: models.GenderMale, Age: 25}
+	female := &models.User{ID: primitive.NewObjectID(), Gender: models.GenderFemale, Age: 25}
+	quota := &models.GenderQuota{Male: 2, Female: 2}
+
+	participants := func(genders ...string) ([]primitive.ObjectID, []models.ParticipantGender) {
+		ids := []primitive.ObjectID{hostID}
+		pg := []models.ParticipantGender{}
+		for _, g := range genders {
+			id := primitive.NewObjectID()
+			ids = append(ids, id)
+			pg = append(pg, models.ParticipantGender{UserID: id, Gender: g})
+		}
+		return ids, pg
+	}
+	withParticipants := func(m models.Meeting, genders ...string) models.Meeting {
+		m.ParticipantIDs, m.ParticipantGenders = participants(genders...)
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		meeting models.Meeting
+		user    *models.User
+		want    string
+	}{
+		{
+			name:    "open",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusRecruiting, MaxParticipants: 4}),
+			user:    male,
+			want:    "",
+		},
+		{
+			name:    "banned comes first",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusFull, MaxParticipants: 1, BannedIDs: []primitive.ObjectID{male.ID}}),
+			user:    male,
+			want:    models.JoinBlockedBanned,
+		},
+		{
+			name:    "age",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusRecruiting, MaxParticipants: 4, AgeRange: [2]int{30, 40}}),
+			user:    male,
+			want:    models.JoinBlockedAge,
+		},
+		{
+			name:    "gender not allowed",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusRecruiting, MaxParticipants: 4, GenderQuota: &models.GenderQuota{Male: 0, Female: 4}}),
+			user:    male,
+			want:    models.JoinBlockedGender,
+		},
+		{
+			name:    "full by count",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusRecruiting, MaxParticipants: 2}, models.GenderFemale),
+			user:    male,
+			want:    models.JoinBlockedFull,
+		},
+		{
+			name:    "same gender waiter ahead",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusRecruiting, MaxParticipants: 4, GenderQuota: quota, WaitlistCount: 1, WaitlistGenders: map[string]int{models.GenderMale: 1}}),
+			user:    male,
+			want:    models.JoinBlockedFull,
+		},
+		{
+			name:    "waiter for a full gender does not block",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusRecruiting, MaxParticipants: 4, GenderQuota: quota, WaitlistCount: 1, WaitlistGenders: map[string]int{models.GenderFemale: 1}}, models.GenderFemale, models.GenderFemale),
+			user:    male,
+			want:    "",
+		},
+		{
+			name:    "own gender full",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusRecruiting, MaxParticipants: 5, GenderQuota: quota}, models.GenderFemale, models.GenderFemale),
+			user:    female,
+			want:    models.JoinBlockedGenderFull,
+		},
+		{
+			name:    "ongoing",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusOngoing, MaxParticipants: 4}),
+			user:    male,
+			want:    models.JoinBlockedClosed,
+		},
+		{
+			name:    "finished at capacity",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusFinished, MaxParticipants: 2}, models.GenderFemale),
+			user:    male,
+			want:    models.JoinBlockedClosed,
+		},
+		{
+			name:    "cancelled with waiters",
+			meeting: withParticipants(models.Meeting{Status: models.MeetingStatusCancelled, MaxParticipants: 4, WaitlistCount: 2}),
+			user:    male,
+			want:    models.JoinBlockedClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.meeting.HostID = hostID
+			if got := joinBlockedReason(&tt.meeting, tt.user); got != tt.want {
+				t.Errorf("joinBlockedReason = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// api/services/notification_service.go
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationService interface {
+	// waitingIDs는 대기열이나 참여 신청에서 빠진 유저
+	NotifyMeetingCancelled(ctx context.Context, meeting *models.Meeting, reason string, waitingIDs []primitive.ObjectID) error
+	NotifyWaitlistPromoted(ctx context.Context, meeting *models.Meeting, userID primitive.ObjectID) error
+	NotifyWaitlistCleared(ctx context.Context, meeting *models.Meeting, userIDs []primitive.ObjectID) error
+	ListNotifications(ctx context.Context, userID string, limit int) ([]models.Notification, error)
+	MarkAllRead(ctx context.Context, userID string) error
+}
+
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	saveRepo         repositories.SaveRepository
+}
+
+func NewNotificationService(nr repositories.NotificationRepository, sr repositories.SaveRepository) NotificationService {
+	return &notificationService{notificationRepo: nr, saveRepo: sr}
+}
+
+// 참여자, 저장한 사람, 기다리던 사람 모두에게. 취소한 방장 본인은 제외
+func (s *notificationService) NotifyMeetingCancelled(ctx context.Context, meeting *models.Meeting, reason string, waitingIDs []primitive.ObjectID) error {
+	saverIDs, err := s.saveRepo.ListUserIDsByMeeting(ctx, meeting.ID)
+	if err != nil {
+		return err
+	}
+
+	body := "'" + meeting.Title + "' 모임이 취소되었습니다."
+	if reason != "" {
+		body += " 사유: " + reason
+	}
+
+	now := time.Now()
+	seen := map[primitive.ObjectID]bool{meeting.HostID: true}
+	var notifications []models.Notification
+
+	recipients := append(append([]primitive.ObjectID{}, meeting.ParticipantIDs...), saverIDs...)
+	for _, uID := range append(recipients, waitingIDs...) {
+		if seen[uID] {
+			continue
+		}
+		seen[uID] = true
+
+		meetingID := meeting.ID
+		notifications = append(notifications, models.Notification{
+			UserID:    uID,
+			Type:      models.NotificationMeetingCancelled,
+			MeetingID: &meetingID,
+			Title:     "모임 취소",
+			Body:      body,
+			CreatedAt: now,
+		})
+	}
+
+	return s.notificationRepo.CreateMany(ctx, notifications)
+}
+
+func (s *notificationService) NotifyWaitlistPromoted(ctx context.Context, meeting *models.Meeting, userID primitive.ObjectID) error {
+	meetingID := meeting.ID
+	return s.notificationRepo.CreateMany(ctx, []models.Notification{{
+		UserID:    userID,
+		Type:      models.NotificationWaitlistPromoted,
+		MeetingID: &meetingID,
+		Title:     "대기 순서 도착",
+		Body:      "자리가 나서 '" + meeting.Title + "' 모임에 참여되었습니다.",
+		CreatedAt: time.Now(),
+	}})
+}
+
+func (s *notificationService) NotifyWaitlistCleared(ctx context.Context, meeting *models.Meeting, userIDs []primitive.ObjectID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	meetingID := meeting.ID
+	notifications := make([]models.Notification, 0, len(userIDs))
+	for _, uID := range userIDs {
+		notifications = append(notifications, models.Notification{
+			UserID:    uID,
+			Type:      models.NotificationWaitlistCleared,
+			MeetingID: &meetingID,
+			Title:     "대기 취소",
+			Body:      "'" + meeting.Title + "' 모임이 승인제로 바뀌어 대기가 취소되었습니다. 참여 신청을 다시 해주세요.",
+			CreatedAt: now,
+		})
+	}
+	return s.notificationRepo.CreateMany(ctx, notifications)
+}
+
+func (s *notificationService) ListNotifications(ctx context.Context, userID string, limit int) ([]models.Notification, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	notifications, err := s.notificationRepo.ListByUser(ctx, uID, int64(limit))
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch notifications", err)
+	}
+	return notifications, nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userID string) error {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	if err := s.notificationRepo.MarkAllRead(ctx, uID, time.Now()); err != nil {
+		return apperr.InternalServerError("failed to mark notifications as read", err)
+	}
+	return nil
+}
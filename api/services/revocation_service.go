@@ -0,0 +1,215 @@
+// api/services/revocation_service.go
+
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 폐기 목록을 메모리에 들고 있다가 주기적으로 DB와 동기화함.
+// 미들웨어는 매 요청마다 IsRevoked만 호출하므로 DB를 타지 않음
+type RevocationService interface {
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	RevokeUserTokens(ctx context.Context, userID string) error
+	RevokeSession(ctx context.Context, sessionID string) error
+	IsRevoked(jti, userID, sessionID string, tokenVersion int) bool
+	Sync(ctx context.Context) error
+	Run(interval time.Duration)
+}
+
+type revocationService struct {
+	revocationRepo repositories.RevocationRepository
+	userRepo       repositories.UserRepository
+
+	mu       sync.RWMutex
+	tokens   map[string]time.Time  // jti -> 만료 시각
+	sessions map[string]time.Time  // sid -> 만료 시각
+	users    map[string]userCutoff // userID -> 이 버전보다 낮은 토큰은 무효
+}
+
+type userCutoff struct {
+	minVersion int
+	expiresAt  time.Time
+}
+
+func NewRevocationService(rr repositories.RevocationRepository, ur repositories.UserRepository) RevocationService {
+	return &revocationService{
+		revocationRepo: rr,
+		userRepo:       ur,
+		tokens:         make(map[string]time.Time),
+		sessions:       make(map[string]time.Time),
+		users:          make(map[string]userCutoff),
+	}
+}
+
+func (s *revocationService) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	if jti == "" {
+		return nil
+	}
+
+	err := s.revocationRepo.Create(ctx, &models.Revocation{
+		Kind:      models.RevocationKindToken,
+		Subject:   jti,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return apperr.InternalServerError("failed to revoke token", err)
+	}
+
+	s.mu.Lock()
+	s.tokens[jti] = expiresAt
+	s.mu.Unlock()
+
+	return nil
+}
+
+// 발급 시각이 아니라 유저 문서의 토큰 버전으로 자름. 올리기 전에 유저를 읽고 발급한 토큰은
+// 같은 순간에 나왔어도 옛 버전을 달고 있어서 폐기되고, 올린 뒤에 발급한 토큰만 살아남음
+func (s *revocationService) RevokeUserTokens(ctx context.Context, userID string) error {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID", err)
+	}
+
+	version, err := s.userRepo.IncrementTokenVersion(ctx, uID)
+	if err != nil {
+		return apperr.InternalServerError("failed to bump token version", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(config.AppConfig.AccessTokenTTL) // 이후엔 기존 토큰이 전부 만료됨
+	err = s.revocationRepo.Create(ctx, &models.Revocation{
+		Kind:       models.RevocationKindUser,
+		Subject:    userID,
+		MinVersion: version,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		return apperr.InternalServerError("failed to revoke user tokens", err)
+	}
+
+	s.mu.Lock()
+	if cutoff, ok := s.users[userID]; !ok || version > cutoff.minVersion {
+		s.users[userID] = userCutoff{minVersion: version, expiresAt: expiresAt}
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// 세션 폐기 기록은 그 세션으로 발급된 마지막 액세스 토큰이 만료될 때까지만 있으면 됨
+func (s *revocationService) RevokeSession(ctx context.Context, sessionID string) error {
+	now := time.Now()
+	expiresAt := now.Add(config.AppConfig.AccessTokenTTL)
+
+	err := s.revocationRepo.Create(ctx, &models.Revocation{
+		Kind:      models.RevocationKindSession,
+		Subject:   sessionID,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return apperr.InternalServerError("failed to revoke session", err)
+	}
+
+	s.mu.Lock()
+	s.sessions[sessionID] = expiresAt
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *revocationService) IsRevoked(jti, userID, sessionID string, tokenVersion int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.tokens[jti]; ok && jti != "" {
+		return true
+	}
+
+	if _, ok := s.sessions[sessionID]; ok && sessionID != "" {
+		return true
+	}
+
+	// tv가 없는 예전 토큰은 0이라서 한 번이라도 전체 폐기가 있었으면 무효
+	if cutoff, ok := s.users[userID]; ok && tokenVersion < cutoff.minVersion {
+		return true
+	}
+
+	return false
+}
+
+func (s *revocationService) Sync(ctx context.Context) error {
+	revocations, err := s.revocationRepo.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	tokens := make(map[string]time.Time)
+	sessions := make(map[string]time.Time)
+	users := make(map[string]userCutoff)
+	for _, r := range revocations {
+		switch r.Kind {
+		case models.RevocationKindToken:
+			tokens[r.Subject] = r.ExpiresAt
+		case models.RevocationKindSession:
+			sessions[r.Subject] = r.ExpiresAt
+		case models.RevocationKindUser:
+			if cutoff, ok := users[r.Subject]; !ok || r.MinVersion > cutoff.minVersion {
+				users[r.Subject] = userCutoff{minVersion: r.MinVersion, expiresAt: r.ExpiresAt}
+			}
+		}
+	}
+
+	// 조회 중에 이 인스턴스에서 추가된 항목이 사라지지 않도록 아직 유효한 기존 항목은 유지
+	now := time.Now()
+	s.mu.Lock()
+	for jti, expiresAt := range s.tokens {
+		if _, ok := tokens[jti]; !ok && expiresAt.After(now) {
+			tokens[jti] = expiresAt
+		}
+	}
+	for sid, expiresAt := range s.sessions {
+		if _, ok := sessions[sid]; !ok && expiresAt.After(now) {
+			sessions[sid] = expiresAt
+		}
+	}
+	for userID, cutoff := range s.users {
+		if cutoff.expiresAt.After(now) && cutoff.minVersion > users[userID].minVersion {
+			users[userID] = cutoff
+		}
+	}
+	s.tokens = tokens
+	s.sessions = sessions
+	s.users = users
+	s.mu.Unlock()
+
+	return nil
+}
+
+// 다른 인스턴스에서 폐기한 토큰은 최대 interval만큼 늦게 반영됨
+func (s *revocationService) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := s.Sync(ctx); err != nil {
+			log.Println("Failed to sync token revocations:", err)
+		}
+		cancel()
+
+		<-ticker.C
+	}
+}
@@ -0,0 +1,132 @@
+// api/services/revocation_service_test.go
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memRevocationRepo struct {
+	repositories.RevocationRepository
+	revocations []models.Revocation
+}
+
+func (r *memRevocationRepo) Create(ctx context.Context, revocation *models.Revocation) error {
+	r.revocations = append(r.revocations, *revocation)
+	return nil
+}
+
+func (r *memRevocationRepo) FindActive(ctx context.Context) ([]models.Revocation, error) {
+	return r.revocations, nil
+}
+
+func (r *memUserRepo) IncrementTokenVersion(ctx context.Context, id primitive.ObjectID) (int, error) {
+	r.users[id].TokenVersion++
+	return r.users[id].TokenVersion, nil
+}
+
+// 같은 초 안에서 폐기 직전에 발급된 토큰도 막히고, 폐기 직후 다시 읽어서 발급한 토큰은 통과해야 함
+func TestRevokeUserTokensSameSecond(t *testing.T) {
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.AccessTokenTTL = 15 * time.Minute
+
+	user := &models.User{ID: primitive.NewObjectID()}
+	users := newMemUserRepo(user)
+	s := NewRevocationService(&memRevocationRepo{}, users)
+	ctx := context.Background()
+
+	issuedBefore := user.TokenVersion
+	if err := s.RevokeUserTokens(ctx, user.ID.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	issuedAfter := user.TokenVersion
+
+	tests := []struct {
+		name    string
+		version int
+		want    bool
+	}{
+		{"issued just before in the same second", issuedBefore, true},
+		{"issued just after in the same second", issuedAfter, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.IsRevoked("jti", user.ID.Hex(), "", tt.version); got != tt.want {
+				t.Errorf("IsRevoked(tv=%d) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+
+	if s.IsRevoked("jti", primitive.NewObjectID().Hex(), "", 0) {
+		t.Error("other user's token revoked")
+	}
+}
+
+func TestIsRevokedUserCutoff(t *testing.T) {
+	s := &revocationService{
+		tokens:   map[string]time.Time{},
+		sessions: map[string]time.Time{},
+		users:    map[string]userCutoff{"u1": {minVersion: 2, expiresAt: time.Now().Add(time.Hour)}},
+	}
+
+	tests := []struct {
+		name    string
+		version int
+		want    bool
+	}{
+		{"older version", 1, true},
+		{"legacy token without tv", 0, true},
+		{"current version", 2, false},
+		{"newer version", 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.IsRevoked("jti", "u1", "", tt.version); got != tt.want {
+				t.Errorf("IsRevoked = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 다른 인스턴스에서 폐기한 버전이 동기화로 들어오고, 로컬의 더 높은 버전은 덮어쓰이지 않아야 함
+func TestSyncKeepsHighestVersion(t *testing.T) {
+	repo := &memRevocationRepo{revocations: []models.Revocation{
+		{Kind: models.RevocationKindUser, Subject: "u1", MinVersion: 1, ExpiresAt: time.Now().Add(time.Hour)},
+		{Kind: models.RevocationKindUser, Subject: "u1", MinVersion: 3, ExpiresAt: time.Now().Add(time.Hour)},
+		{Kind: models.RevocationKindUser, Subject: "u2", MinVersion: 1, ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+	s := NewRevocationService(repo, nil).(*revocationService)
+	s.users["u2"] = userCutoff{minVersion: 4, expiresAt: time.Now().Add(time.Hour)}
+
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !s.IsRevoked("jti", "u1", "", 2) || s.IsRevoked("jti", "u1", "", 3) {
+		t.Errorf("u1 cutoff = %+v, want 3", s.users["u1"])
+	}
+	if !s.IsRevoked("jti", "u2", "", 3) {
+		t.Errorf("u2 cutoff = %+v, want 4", s.users["u2"])
+	}
+}
+
+// jti가 없는 레거시 토큰끼리 폐기가 번지면 안 됨
+func TestIsRevokedIgnoresEmptyJTI(t *testing.T) {
+	s := &revocationService{
+		tokens:   map[string]time.Time{"": time.Now().Add(time.Hour)},
+		sessions: map[string]time.Time{},
+		users:    map[string]userCutoff{},
+	}
+	if s.IsRevoked("", "u1", "", 0) {
+		t.Fatal("empty jti treated as revoked")
+	}
+}
@@ -0,0 +1,144 @@
+// api/services/session_service.go
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionService interface {
+	StartSession(ctx context.Context, userID primitive.ObjectID, provider string, client models.ClientInfo) (*models.Session, error)
+	TouchSession(ctx context.Context, sessionID primitive.ObjectID, client models.ClientInfo) error
+	ListSessions(ctx context.Context, userID, currentSessionID string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID string) error
+}
+
+type sessionService struct {
+	sessionRepo      repositories.SessionRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revocations      RevocationService
+}
+
+func NewSessionService(sr repositories.SessionRepository, rtr repositories.RefreshTokenRepository, rs RevocationService) SessionService {
+	return &sessionService{sessionRepo: sr, refreshTokenRepo: rtr, revocations: rs}
+}
+
+func (s *sessionService) StartSession(ctx context.Context, userID primitive.ObjectID, provider string, client models.ClientInfo) (*models.Session, error) {
+	deviceName := client.DeviceName
+	if deviceName == "" {
+		deviceName = "Unknown device"
+	}
+
+	now := time.Now()
+	session := &models.Session{
+		UserID:     userID,
+		Provider:   provider,
+		DeviceName: deviceName,
+		UserAgent:  client.UserAgent,
+		LastSeenIP: client.IP,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(config.AppConfig.RefreshTokenTTL),
+		CreatedAt:  now,
+	}
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, apperr.InternalServerError("failed to create session", err)
+	}
+
+	return session, nil
+}
+
+// 토큰 갱신 시점에 세션이 아직 유효한지 확인하고 마지막 접속 정보를 갱신함
+func (s *sessionService) TouchSession(ctx context.Context, sessionID primitive.ObjectID, client models.ClientInfo) error {
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch session", err)
+	}
+	if session == nil || session.RevokedAt != nil {
+		return apperr.Unauthorized("session has been revoked", nil)
+	}
+
+	// 갱신하면 새 리프레시 토큰이 발급되므로 세션 만료도 같이 늦춤
+	if err := s.sessionRepo.Touch(ctx, sessionID, client.IP, time.Now().Add(config.AppConfig.RefreshTokenTTL)); err != nil {
+		return apperr.InternalServerError("failed to update session", err)
+	}
+
+	return nil
+}
+
+func (s *sessionService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]models.Session, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	sessions, err := s.sessionRepo.ListActiveByUser(ctx, uID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to list sessions", err)
+	}
+
+	for i := range sessions {
+		sessions[i].IsCurrent = sessions[i].ID.Hex() == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	sID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return apperr.BadRequest("invalid session ID format", err)
+	}
+
+	revoked, err := s.sessionRepo.Revoke(ctx, sID, uID)
+	if err != nil {
+		return apperr.InternalServerError("failed to revoke session", err)
+	}
+	if !revoked {
+		return apperr.NotFound("session not found", nil)
+	}
+
+	if err := s.revocations.RevokeSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllBySession(ctx, sID); err != nil {
+		return apperr.InternalServerError("failed to revoke refresh tokens", err)
+	}
+
+	return nil
+}
+
+func (s *sessionService) RevokeAllSessions(ctx context.Context, userID string) error {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	if err := s.revocations.RevokeUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllByUser(ctx, uID); err != nil {
+		return apperr.InternalServerError("failed to revoke refresh tokens", err)
+	}
+
+	if err := s.sessionRepo.RevokeAllByUser(ctx, uID); err != nil {
+		return apperr.InternalServerError("failed to revoke sessions", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,499 @@
+// api/services/token_service.go
+
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"github.com/seojoonrp/bbiyong-backend/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	SigningAlgES256 = "ES256"
+	SigningAlgEdDSA = "EdDSA"
+)
+
+// typ 클레임. 같은 키로 서명하므로 용도가 다른 토큰끼리 섞어 쓰지 못하게 구분함
+const (
+	tokenTypeAccess    = "access"
+	tokenTypeChallenge = "2fa_challenge"
+)
+
+// 모르는 kid가 들어왔을 때 DB를 다시 읽는 최소 간격
+const unknownKIDReloadInterval = 10 * time.Second
+
+// 액세스 토큰 발급/검증. 서명 키는 DB에 두고 인스턴스끼리 공유하며 주기적으로 교체함.
+// 공개키는 JWKS로 내보내서 다른 서비스도 시크릿 없이 검증할 수 있음
+type TokenService interface {
+	IssueAccessToken(userID, sessionID, role string, tokenVersion int, profileSet, deletionPending bool) (string, error)
+	ParseAccessToken(tokenString string) (*models.AccessClaims, error)
+	IssueChallengeToken(userID string, tokenVersion int) (string, error)
+	ParseChallengeToken(tokenString string) (*models.AccessClaims, error)
+	JWKS() JWKSet
+	Rotate(ctx context.Context) error
+	Run(interval time.Duration)
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	KTY string `json:"kty"`
+	CRV string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	KID string `json:"kid"`
+	ALG string `json:"alg"`
+	USE string `json:"use"`
+}
+
+type signingKey struct {
+	kid         string
+	method      jwt.SigningMethod
+	private     crypto.Signer
+	activatesAt time.Time
+	retiresAt   time.Time
+	expiresAt   time.Time
+}
+
+type tokenService struct {
+	signingKeyRepo repositories.SigningKeyRepository
+
+	mu         sync.RWMutex
+	keys       map[string]*signingKey
+	ordered    []*signingKey // activatesAt 오름차순
+	lastReload time.Time
+}
+
+func NewTokenService(skr repositories.SigningKeyRepository) TokenService {
+	return &tokenService{
+		signingKeyRepo: skr,
+		keys:           make(map[string]*signingKey),
+	}
+}
+
+func (s *tokenService) IssueAccessToken(userID, sessionID, role string, tokenVersion int, profileSet, deletionPending bool) (string, error) {
+	now := time.Now()
+	return s.sign(jwt.MapClaims{
+		"typ":     tokenTypeAccess,
+		"user_id": userID,
+		"sid":     sessionID,
+		"role":    role,
+		"tv":      tokenVersion,                  // 유저 토큰 버전. 전체 폐기되면 이보다 높아짐
+		"ps":      profileSet,                    // 프로필 완료 여부. false면 미들웨어가 DB를 다시 확인함
+		"dp":      deletionPending,               // 탈퇴 유예 중. true면 미들웨어가 DB를 다시 확인함
+		"jti":     primitive.NewObjectID().Hex(), // 로그아웃 시 개별 토큰 폐기용
+		"iat":     now.Unix(),
+		"exp":     now.Add(config.AppConfig.AccessTokenTTL).Unix(),
+	})
+}
+
+func (s *tokenService) ParseAccessToken(tokenString string) (*models.AccessClaims, error) {
+	claims, legacy, err := s.parse(tokenString, tokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, _ := claims["sid"].(string)
+
+	// 역할 클레임이 없는 예전 토큰은 일반 유저
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = models.RoleUser
+	}
+
+	parsed, err := s.toClaims(claims, sessionID, role, legacy)
+	if err != nil {
+		return nil, err
+	}
+	parsed.ProfileSet, _ = claims["ps"].(bool)
+	parsed.DeletionPending, _ = claims["dp"].(bool)
+	return parsed, nil
+}
+
+// 비밀번호 확인 후 2단계 인증을 기다리는 동안만 쓰는 토큰. 액세스 토큰으로는 쓸 수 없음
+func (s *tokenService) IssueChallengeToken(userID string, tokenVersion int) (string, error) {
+	now := time.Now()
+	return s.sign(jwt.MapClaims{
+		"typ":     tokenTypeChallenge,
+		"user_id": userID,
+		"tv":      tokenVersion,                  // 2단계 인증 전에 비밀번호가 재설정되면 무효
+		"jti":     primitive.NewObjectID().Hex(), // 한 번 통과하면 폐기
+		"iat":     now.Unix(),
+		"exp":     now.Add(config.AppConfig.TwoFactorChallengeTTL).Unix(),
+	})
+}
+
+// 세션과 역할은 비어 있음
+func (s *tokenService) ParseChallengeToken(tokenString string) (*models.AccessClaims, error) {
+	claims, _, err := s.parse(tokenString, tokenTypeChallenge)
+	if err != nil {
+		return nil, err
+	}
+	return s.toClaims(claims, "", "", false)
+}
+
+func (s *tokenService) sign(claims jwt.MapClaims) (string, error) {
+	key := s.currentKey(time.Now())
+	if key == nil {
+		return "", apperr.InternalServerError("no active signing key", nil)
+	}
+
+	claims["iss"] = config.AppConfig.JWTIssuer
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+
+	signed, err := token.SignedString(key.private)
+	if err != nil {
+		return "", apperr.InternalServerError("failed to sign token", err)
+	}
+	return signed, nil
+}
+
+// 레거시 HS256 토큰이면 legacy가 true
+func (s *tokenService) parse(tokenString, tokenType string) (jwt.MapClaims, bool, error) {
+	token, err := jwt.Parse(tokenString, s.keyfunc,
+		jwt.WithValidMethods([]string{SigningAlgES256, SigningAlgEdDSA, jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, false, apperr.Unauthorized("invalid or expired token", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, false, apperr.Unauthorized("invalid token claims", nil)
+	}
+
+	// 레거시 HS256 토큰에는 iss와 typ가 없고, 전부 액세스 토큰임
+	if token.Method == jwt.SigningMethodHS256 {
+		if tokenType != tokenTypeAccess {
+			return nil, false, apperr.Unauthorized("invalid token type", nil)
+		}
+		return claims, true, nil
+	}
+
+	if iss, _ := claims.GetIssuer(); iss != config.AppConfig.JWTIssuer {
+		return nil, false, apperr.Unauthorized("invalid token issuer", nil)
+	}
+	if typ, _ := claims["typ"].(string); typ != tokenType {
+		return nil, false, apperr.Unauthorized("invalid token type", nil)
+	}
+	return claims, false, nil
+}
+
+// 레거시 토큰에는 user_id와 exp만 있음. jti가 비어 있으면 토큰 단위 폐기 없이 만료로만 끝남
+func (s *tokenService) toClaims(claims jwt.MapClaims, sessionID, role string, legacy bool) (*models.AccessClaims, error) {
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return nil, apperr.Unauthorized("invalid user ID in token", nil)
+	}
+
+	jti, _ := claims["jti"].(string)
+	issuedAt, _ := claims.GetIssuedAt()
+	expiresAt, _ := claims.GetExpirationTime()
+	if expiresAt == nil || (!legacy && (jti == "" || issuedAt == nil)) {
+		return nil, apperr.Unauthorized("token is missing required claims", nil)
+	}
+
+	// JSON 숫자라서 float64로 들어옴
+	tokenVersion, _ := claims["tv"].(float64)
+
+	parsed := &models.AccessClaims{
+		TokenVersion: int(tokenVersion),
+		UserID:       userID,
+		SessionID:    sessionID,
+		Role:         role,
+		JTI:          jti,
+		ExpiresAt:    expiresAt.Time,
+	}
+	// 레거시 토큰은 iat가 없을 수 있음
+	if issuedAt != nil {
+		parsed.IssuedAt = issuedAt.Time
+	}
+	return parsed, nil
+}
+
+func (s *tokenService) keyfunc(token *jwt.Token) (interface{}, error) {
+	// HS256은 비대칭 키로 옮겨가는 동안에만 허용
+	if token.Method == jwt.SigningMethodHS256 {
+		if !time.Now().Before(config.AppConfig.JWTLegacyHS256Until) {
+			return nil, errors.New("HS256 tokens are no longer accepted")
+		}
+		return []byte(config.AppConfig.JWTSecret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no kid")
+	}
+
+	key := s.lookupKey(kid)
+	if key == nil {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if key.method.Alg() != token.Method.Alg() {
+		return nil, fmt.Errorf("kid %q does not use %s", kid, token.Method.Alg())
+	}
+	return key.private.Public(), nil
+}
+
+// 다른 인스턴스가 막 만든 키일 수 있으므로 모르는 kid면 DB를 한 번 더 읽어 봄
+func (s *tokenService) lookupKey(kid string) *signingKey {
+	s.mu.RLock()
+	key, ok := s.keys[kid]
+	canReload := time.Since(s.lastReload) >= unknownKIDReloadInterval
+	s.mu.RUnlock()
+
+	if ok {
+		return key
+	}
+	if !canReload {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.reload(ctx); err != nil {
+		log.Println("Failed to reload signing keys:", err)
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[kid]
+}
+
+// 지금 서명에 쓸 키. 활성 구간이 겹치면 가장 최근에 활성화된 키를 씀
+func (s *tokenService) currentKey(now time.Time) *signingKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := len(s.ordered) - 1; i >= 0; i-- {
+		key := s.ordered[i]
+		if !key.activatesAt.After(now) && key.retiresAt.After(now) {
+			return key
+		}
+	}
+	return nil
+}
+
+// 만료되지 않은 키 전체를 공개. 다음 차례 키도 활성화 전에 미리 노출되어 검증 측 캐시에 들어감
+func (s *tokenService) JWKS() JWKSet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := JWKSet{Keys: make([]JWK, 0, len(s.ordered))}
+	for _, key := range s.ordered {
+		jwk, err := publicJWK(key)
+		if err != nil {
+			log.Printf("Failed to encode signing key %s: %v", key.kid, err)
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// 현재 키가 없거나 은퇴가 가까우면 다음 키를 만들어 둠.
+// 여러 인스턴스가 동시에 만들더라도 activates_at 유니크 인덱스로 하나만 저장됨
+func (s *tokenService) Rotate(ctx context.Context) error {
+	if err := s.reload(ctx); err != nil {
+		return err
+	}
+
+	cfg := config.AppConfig
+	now := time.Now()
+
+	var activatesAt time.Time
+	s.mu.RLock()
+	if len(s.ordered) == 0 {
+		activatesAt = now
+	} else {
+		latest := s.ordered[len(s.ordered)-1]
+		switch {
+		case !latest.retiresAt.After(now):
+			activatesAt = now
+		case latest.retiresAt.Sub(now) <= cfg.JWTKeyPublishLead:
+			activatesAt = latest.retiresAt
+		}
+	}
+	s.mu.RUnlock()
+
+	if activatesAt.IsZero() {
+		return nil
+	}
+
+	key, err := s.generateKey(activatesAt)
+	if err != nil {
+		return err
+	}
+
+	if err := s.signingKeyRepo.Create(ctx, key); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	log.Printf("Generated signing key %s (%s), active from %s", key.KID, key.Algorithm, key.ActivatesAt.Format(time.RFC3339))
+
+	return s.reload(ctx)
+}
+
+// 키를 교체해도 검증 측이 JWKS를 다시 받을 시간이 있도록 interval은 publish lead보다 충분히 짧아야 함
+func (s *tokenService) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := s.Rotate(ctx); err != nil {
+			log.Println("Failed to rotate signing keys:", err)
+		}
+		cancel()
+	}
+}
+
+func (s *tokenService) generateKey(activatesAt time.Time) (*models.SigningKey, error) {
+	cfg := config.AppConfig
+
+	var private crypto.Signer
+	var err error
+	switch cfg.JWTSigningAlgorithm {
+	case SigningAlgEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := utils.EncryptSecret(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), cfg.DataEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	retiresAt := activatesAt.Add(cfg.JWTKeyRotationPeriod)
+	return &models.SigningKey{
+		ID:            primitive.NewObjectID(),
+		KID:           primitive.NewObjectID().Hex(),
+		Algorithm:     cfg.JWTSigningAlgorithm,
+		PrivateKeyPEM: encoded,
+		ActivatesAt:   activatesAt,
+		RetiresAt:     retiresAt,
+		// 은퇴 직전에 발급된 토큰이 만료될 때까지는 검증할 수 있어야 함
+		ExpiresAt: retiresAt.Add(cfg.AccessTokenTTL + time.Hour),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (s *tokenService) reload(ctx context.Context) error {
+	stored, err := s.signingKeyRepo.FindUnexpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]*signingKey, len(stored))
+	ordered := make([]*signingKey, 0, len(stored))
+	for _, sk := range stored {
+		key, err := decodeSigningKey(sk)
+		if err != nil {
+			log.Printf("Skipping unusable signing key %s: %v", sk.KID, err)
+			continue
+		}
+		keys[key.kid] = key
+		ordered = append(ordered, key)
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.ordered = ordered
+	s.lastReload = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+func decodeSigningKey(sk models.SigningKey) (*signingKey, error) {
+	raw, err := utils.DecryptSecret(sk.PrivateKeyPEM, config.AppConfig.DataEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(raw))
+	if block == nil {
+		return nil, errors.New("invalid PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{
+		kid:         sk.KID,
+		activatesAt: sk.ActivatesAt,
+		retiresAt:   sk.RetiresAt,
+		expiresAt:   sk.ExpiresAt,
+	}
+	switch p := parsed.(type) {
+	case *ecdsa.PrivateKey:
+		key.method, key.private = jwt.SigningMethodES256, p
+	case ed25519.PrivateKey:
+		key.method, key.private = jwt.SigningMethodEdDSA, p
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	if key.method.Alg() != sk.Algorithm {
+		return nil, fmt.Errorf("key type does not match algorithm %s", sk.Algorithm)
+	}
+	return key, nil
+}
+
+func publicJWK(key *signingKey) (JWK, error) {
+	jwk := JWK{KID: key.kid, ALG: key.method.Alg(), USE: "sig"}
+
+	switch pub := key.private.Public().(type) {
+	case *ecdsa.PublicKey:
+		ecdh, err := pub.ECDH()
+		if err != nil {
+			return JWK{}, err
+		}
+		// 비압축 포인트: 0x04 || X(32) || Y(32)
+		point := ecdh.Bytes()
+		jwk.KTY = "EC"
+		jwk.CRV = "P-256"
+		jwk.X = base64.RawURLEncoding.EncodeToString(point[1:33])
+		jwk.Y = base64.RawURLEncoding.EncodeToString(point[33:])
+	case ed25519.PublicKey:
+		jwk.KTY = "OKP"
+		jwk.CRV = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return JWK{}, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return jwk, nil
+}
@@ -0,0 +1,74 @@
+// api/services/token_service_test.go
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seojoonrp/bbiyong-backend/config"
+)
+
+// 기준 커밋에서 발급하던 모양 그대로: user_id와 exp만 있음
+func signLegacyToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(config.AppConfig.JWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestParseLegacyAccessToken(t *testing.T) {
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.JWTSecret = "legacy-secret"
+
+	exp := time.Now().Add(time.Hour).Unix()
+	s := NewTokenService(nil)
+
+	tests := []struct {
+		name    string
+		until   time.Time
+		claims  jwt.MapClaims
+		wantErr bool
+	}{
+		{"accepted inside window", time.Now().Add(time.Hour), jwt.MapClaims{"user_id": "u1", "exp": exp}, false},
+		{"rejected after window", time.Now().Add(-time.Hour), jwt.MapClaims{"user_id": "u1", "exp": exp}, true},
+		{"requires exp", time.Now().Add(time.Hour), jwt.MapClaims{"user_id": "u1"}, true},
+		{"requires user_id", time.Now().Add(time.Hour), jwt.MapClaims{"exp": exp}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.AppConfig.JWTLegacyHS256Until = tt.until
+
+			claims, err := s.ParseAccessToken(signLegacyToken(t, tt.claims))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.UserID != "u1" || claims.JTI != "" || !claims.IssuedAt.IsZero() {
+				t.Errorf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestLegacyTokenNotChallengeToken(t *testing.T) {
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.JWTSecret = "legacy-secret"
+	config.AppConfig.JWTLegacyHS256Until = time.Now().Add(time.Hour)
+
+	token := signLegacyToken(t, jwt.MapClaims{"user_id": "u1", "exp": time.Now().Add(time.Hour).Unix()})
+	if _, err := NewTokenService(nil).ParseChallengeToken(token); err == nil {
+		t.Fatal("legacy token accepted as challenge token")
+	}
+}
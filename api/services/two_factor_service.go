@@ -0,0 +1,223 @@
+// api/services/two_factor_service.go
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"github.com/seojoonrp/bbiyong-backend/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const recoveryCodeCount = 10
+
+// 로컬 비밀번호 로그인에 붙는 TOTP 2단계 인증. 소셜 로그인은 제공자 쪽 보안을 따름
+type TwoFactorService interface {
+	Setup(ctx context.Context, userID string) (*models.TwoFactorSetup, error)
+	Enable(ctx context.Context, userID, code string) ([]string, error)
+	Disable(ctx context.Context, userID, code string, client models.ClientInfo) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string, client models.ClientInfo) ([]string, error)
+	VerifyCode(ctx context.Context, user *models.User, code string) (bool, error)
+}
+
+type twoFactorService struct {
+	userRepo      repositories.UserRepository
+	loginThrottle LoginThrottleService
+}
+
+func NewTwoFactorService(ur repositories.UserRepository, lts LoginThrottleService) TwoFactorService {
+	return &twoFactorService{userRepo: ur, loginThrottle: lts}
+}
+
+// 새 시크릿을 대기 상태로 저장. Enable에서 첫 코드를 확인해야 실제로 켜짐
+func (s *twoFactorService) Setup(ctx context.Context, userID string) (*models.TwoFactorSetup, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Password == "" {
+		return nil, apperr.BadRequest("two-factor authentication requires a local password", nil)
+	}
+	if user.TwoFactor != nil && user.TwoFactor.Enabled {
+		return nil, apperr.Conflict("two-factor authentication already enabled", nil)
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to generate secret", err)
+	}
+	encrypted, err := utils.EncryptSecret(secret, config.AppConfig.DataEncryptionKey)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to encrypt secret", err)
+	}
+
+	updated, err := s.userRepo.SetPendingTwoFactor(ctx, user.ID, encrypted)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to save secret", err)
+	}
+	if !updated {
+		return nil, apperr.Conflict("two-factor authentication already enabled", nil)
+	}
+
+	return &models.TwoFactorSetup{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPProvisioningURI(secret, config.AppConfig.TwoFactorIssuer, user.Username),
+	}, nil
+}
+
+// 복구 코드 원문은 여기서 한 번만 내려줌
+func (s *twoFactorService) Enable(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactor == nil || user.TwoFactor.PendingSecret == "" {
+		if user.TwoFactor != nil && user.TwoFactor.Enabled {
+			return nil, apperr.Conflict("two-factor authentication already enabled", nil)
+		}
+		return nil, apperr.BadRequest("two-factor setup has not been started", nil)
+	}
+
+	secret, err := utils.DecryptSecret(user.TwoFactor.PendingSecret, config.AppConfig.DataEncryptionKey)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to decrypt secret", err)
+	}
+	step, ok := utils.ValidateTOTP(secret, code, time.Now(), 1)
+	if !ok {
+		return nil, apperr.BadRequest("invalid two-factor code", nil)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := s.userRepo.EnableTwoFactor(ctx, user.ID, user.TwoFactor.PendingSecret, hashes, step)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to enable two-factor authentication", err)
+	}
+	if !enabled {
+		return nil, apperr.Conflict("two-factor setup changed, start again", nil)
+	}
+	return codes, nil
+}
+
+func (s *twoFactorService) Disable(ctx context.Context, userID, code string, client models.ClientInfo) error {
+	user, err := s.getEnabledUser(ctx, userID, code, client)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.DisableTwoFactor(ctx, user.ID); err != nil {
+		return apperr.InternalServerError("failed to disable two-factor authentication", err)
+	}
+	return nil
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID, code string, client models.ClientInfo) ([]string, error) {
+	user, err := s.getEnabledUser(ctx, userID, code, client)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SetRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		return nil, apperr.InternalServerError("failed to save recovery codes", err)
+	}
+	return codes, nil
+}
+
+// TOTP 코드나 복구 코드 중 하나. 어느 쪽이든 한 번 쓰면 다시 못 씀
+func (s *twoFactorService) VerifyCode(ctx context.Context, user *models.User, code string) (bool, error) {
+	if user.TwoFactor == nil || !user.TwoFactor.Enabled {
+		return false, nil
+	}
+
+	secret, err := utils.DecryptSecret(user.TwoFactor.Secret, config.AppConfig.DataEncryptionKey)
+	if err != nil {
+		return false, apperr.InternalServerError("failed to decrypt secret", err)
+	}
+
+	if step, ok := utils.ValidateTOTP(secret, code, time.Now(), 1); ok {
+		used, err := s.userRepo.UseTwoFactorStep(ctx, user.ID, step)
+		if err != nil {
+			return false, apperr.InternalServerError("failed to record two-factor code", err)
+		}
+		return used, nil
+	}
+
+	normalized := utils.NormalizeRecoveryCode(code)
+	if normalized == "" {
+		return false, nil
+	}
+	used, err := s.userRepo.UseRecoveryCode(ctx, user.ID, utils.HashToken(normalized))
+	if err != nil {
+		return false, apperr.InternalServerError("failed to record recovery code", err)
+	}
+	return used, nil
+}
+
+// 끄거나 복구 코드를 다시 만들 때도 현재 코드를 확인함
+// 액세스 토큰만 훔쳐서 코드를 대입하지 못하도록 로그인과 같은 실패 카운터를 씀
+func (s *twoFactorService) getEnabledUser(ctx context.Context, userID, code string, client models.ClientInfo) (*models.User, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactor == nil || !user.TwoFactor.Enabled {
+		return nil, apperr.BadRequest("two-factor authentication is not enabled", nil)
+	}
+
+	if err := s.loginThrottle.Check(ctx, user.Username, client.IP); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.VerifyCode(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		s.loginThrottle.RecordFailure(ctx, user.Username, client.IP, &user.ID)
+		return nil, apperr.BadRequest("invalid two-factor code", nil)
+	}
+	s.loginThrottle.RecordSuccess(ctx, user.Username)
+	return user, nil
+}
+
+func (s *twoFactorService) getUser(ctx context.Context, userID string) (*models.User, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, uID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by id", err)
+	}
+	if user == nil {
+		return nil, apperr.NotFound("user not found", nil)
+	}
+	return user, nil
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, apperr.InternalServerError("failed to generate recovery codes", err)
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	}
+	return codes, hashes, nil
+}
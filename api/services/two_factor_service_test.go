@@ -0,0 +1,61 @@
+// api/services/two_factor_service_test.go
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 실패 횟수가 limit에 닿으면 잠김
+type countingThrottle struct {
+	limit    int
+	failures int
+}
+
+func (t *countingThrottle) Check(ctx context.Context, username, ip string) error {
+	if t.failures >= t.limit {
+		return apperr.TooManyRequests("too many attempts", nil)
+	}
+	return nil
+}
+
+func (t *countingThrottle) RecordFailure(ctx context.Context, username, ip string, userID *primitive.ObjectID) {
+	t.failures++
+}
+
+func (t *countingThrottle) RecordSuccess(ctx context.Context, username string) {
+	t.failures = 0
+}
+
+func (r *memUserRepo) UseRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	return false, nil
+}
+
+func TestDisableTwoFactorIsThrottled(t *testing.T) {
+	user := &models.User{
+		ID:        primitive.NewObjectID(),
+		Username:  "alice",
+		TwoFactor: &models.TwoFactor{Enabled: true, Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"},
+	}
+	throttle := &countingThrottle{limit: 3}
+	s := NewTwoFactorService(newMemUserRepo(user), throttle)
+	client := models.ClientInfo{IP: "203.0.113.1"}
+
+	for i := 0; i < 3; i++ {
+		if got := statusOf(s.Disable(context.Background(), user.ID.Hex(), "000000", client)); got != http.StatusBadRequest {
+			t.Fatalf("attempt %d: status = %d, want %d", i+1, got, http.StatusBadRequest)
+		}
+	}
+	if got := statusOf(s.Disable(context.Background(), user.ID.Hex(), "000000", client)); got != http.StatusTooManyRequests {
+		t.Fatalf("after lockout: status = %d, want %d", got, http.StatusTooManyRequests)
+	}
+	if _, err := s.RegenerateRecoveryCodes(context.Background(), user.ID.Hex(), "000000", client); statusOf(err) != http.StatusTooManyRequests {
+		t.Fatalf("regenerate after lockout: status = %d, want %d", statusOf(err), http.StatusTooManyRequests)
+	}
+}
@@ -4,25 +4,47 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/seojoonrp/bbiyong-backend/api/repositories"
 	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
 	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// 웹소켓 허브가 구현함. 접속 중인 클라이언트의 발신자 이름/사진을 바로 바꿈
+type ProfileBroadcaster interface {
+	UpdateUserProfile(userID, nickname, profileURI string)
+}
+
 type UserService interface {
 	GetUserByID(ctx context.Context, id string) (*models.User, error)
+	UpdateProfile(ctx context.Context, userID string, req models.UpdateProfileRequest) (*models.User, error)
+	GetMe(ctx context.Context, userID string) (*models.MyProfile, error)
+	GetProfile(ctx context.Context, viewerID, targetID string) (*models.PublicProfile, error)
 }
 
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo    repositories.UserRepository
+	meetingRepo repositories.MeetingRepository
+	friendRepo  repositories.FriendRepository
+	profiles    ProfileBroadcaster
 }
 
-func NewUserService(ur repositories.UserRepository) UserService {
-	return &userService{userRepo: ur}
+func NewUserService(ur repositories.UserRepository, mr repositories.MeetingRepository, fr repositories.FriendRepository, pb ProfileBroadcaster) UserService {
+	return &userService{userRepo: ur, meetingRepo: mr, friendRepo: fr, profiles: pb}
 }
 
+// 공개 프로필에 함께 보여주는 공통 친구 수
+const mutualFriendPreviewLimit = 3
+
 func (s *userService) GetUserByID(ctx context.Context, id string) (*models.User, error) {
 	uID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -38,3 +60,302 @@ func (s *userService) GetUserByID(ctx context.Context, id string) (*models.User,
 	}
 	return user, nil
 }
+
+func (s *userService) GetMe(ctx context.Context, userID string) (*models.MyProfile, error) {
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	friendIDs, err := s.friendRepo.FindFriendIDs(ctx, user.ID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch friends", err)
+	}
+
+	counts, err := s.activityCounts(ctx, user.ID, len(friendIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MyProfile{User: *user, ActivityCounts: *counts}, nil
+}
+
+// 보는 사람과의 관계에 따라 필드를 거름. 이메일, 정확한 위치, 로그인 수단은 본인에게도 여기선 안 보여줌
+func (s *userService) GetProfile(ctx context.Context, viewerID, targetID string) (*models.PublicProfile, error) {
+	vID, err := primitive.ObjectIDFromHex(viewerID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+	tID, err := primitive.ObjectIDFromHex(targetID)
+	if err != nil {
+		return nil, apperr.BadRequest("invalid user ID format", err)
+	}
+
+	target, err := s.userRepo.FindByID(ctx, tID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by id", err)
+	}
+	// 탈퇴 유예 중이거나 온보딩 전인 유저는 남에게 없는 유저처럼 보임
+	if target == nil || (vID != tID && (target.DeletionScheduledAt != nil || !target.IsProfileSet)) {
+		return nil, apperr.NotFound("user not found", nil)
+	}
+
+	targetFriends, err := s.friendRepo.FindFriendIDs(ctx, tID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch friends", err)
+	}
+
+	counts, err := s.activityCounts(ctx, tID, len(targetFriends))
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &models.PublicProfile{
+		ID:             target.ID,
+		Nickname:       target.Nickname,
+		ProfileURI:     target.ProfileURI,
+		Level:          target.Level,
+		RegionName:     target.RegionName,
+		ActivityCounts: *counts,
+		Relationship:   models.RelationshipStranger,
+		MutualFriends:  []models.UserSummary{},
+	}
+
+	if vID == tID {
+		profile.Relationship = models.RelationshipSelf
+	} else {
+		friendship, err := s.friendRepo.FindByUserIDs(ctx, vID, tID)
+		if err != nil {
+			return nil, apperr.InternalServerError("failed to fetch friendship", err)
+		}
+		if friendship != nil {
+			switch {
+			case friendship.Status == models.FriendStatusAccepted:
+				profile.Relationship = models.RelationshipFriend
+			case friendship.Status == models.FriendStatusPending && friendship.RequesterID == vID:
+				profile.FriendRequest = models.FriendRequestSent
+			case friendship.Status == models.FriendStatusPending:
+				profile.FriendRequest = models.FriendRequestReceived
+			}
+		}
+
+		if err := s.fillMutualFriends(ctx, profile, vID, targetFriends); err != nil {
+			return nil, err
+		}
+	}
+
+	if profile.Relationship != models.RelationshipStranger {
+		age := target.Age
+		profile.Age = &age
+		profile.Gender = target.Gender
+	}
+
+	return profile, nil
+}
+
+func (s *userService) fillMutualFriends(ctx context.Context, profile *models.PublicProfile, viewerID primitive.ObjectID, targetFriends []primitive.ObjectID) error {
+	viewerFriends, err := s.friendRepo.FindFriendIDs(ctx, viewerID)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch friends", err)
+	}
+
+	viewerSet := make(map[primitive.ObjectID]struct{}, len(viewerFriends))
+	for _, id := range viewerFriends {
+		viewerSet[id] = struct{}{}
+	}
+
+	var mutual []primitive.ObjectID
+	for _, id := range targetFriends {
+		if _, ok := viewerSet[id]; ok {
+			mutual = append(mutual, id)
+		}
+	}
+	if len(mutual) == 0 {
+		return nil
+	}
+
+	summaries, err := s.userRepo.FindSummaries(ctx, mutual, 0)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch mutual friends", err)
+	}
+
+	// 탈퇴 유예 중인 친구는 수에서도 뺌
+	profile.MutualFriendCount = len(summaries)
+	if len(summaries) > mutualFriendPreviewLimit {
+		summaries = summaries[:mutualFriendPreviewLimit]
+	}
+	profile.MutualFriends = summaries
+	return nil
+}
+
+func (s *userService) activityCounts(ctx context.Context, userID primitive.ObjectID, friendCount int) (*models.ActivityCounts, error) {
+	hosted, err := s.meetingRepo.CountHostedBy(ctx, userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to count hosted meetings", err)
+	}
+
+	attended, err := s.meetingRepo.CountAttendedBy(ctx, userID, time.Now())
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to count attended meetings", err)
+	}
+
+	return &models.ActivityCounts{
+		HostedCount:   hosted,
+		AttendedCount: attended,
+		FriendCount:   int64(friendCount),
+	}, nil
+}
+
+// 온보딩이 끝난 뒤의 프로필 수정. 보낸 필드만 바꿈
+func (s *userService) UpdateProfile(ctx context.Context, userID string, req models.UpdateProfileRequest) (*models.User, error) {
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsProfileSet {
+		return nil, apperr.BadRequest("profile is not set yet", nil)
+	}
+
+	now := time.Now()
+	updates := bson.M{}
+	var nicknameChangeableBefore *time.Time
+
+	if req.Nickname != nil {
+		nickname := strings.TrimSpace(*req.Nickname)
+		if err := validateNickname(nickname); err != nil {
+			return nil, err
+		}
+		if nickname != user.Nickname {
+			cooldown := config.AppConfig.NicknameChangeCooldown
+			if user.NicknameChangedAt != nil && now.Before(user.NicknameChangedAt.Add(cooldown)) {
+				return nil, nicknameCooldownError(user.NicknameChangedAt.Add(cooldown).Sub(now))
+			}
+			before := now.Add(-cooldown)
+			nicknameChangeableBefore = &before
+			updates["nickname"] = nickname
+			updates["nickname_changed_at"] = now
+		}
+	}
+	if req.ProfileURI != nil {
+		if err := validateProfileURI(*req.ProfileURI); err != nil {
+			return nil, err
+		}
+		updates["profile_uri"] = *req.ProfileURI
+	}
+	if req.Age != nil {
+		if err := validateAge(*req.Age); err != nil {
+			return nil, err
+		}
+		updates["age"] = *req.Age
+	}
+	if req.Location != nil {
+		if err := validateLocation(*req.Location); err != nil {
+			return nil, err
+		}
+		updates["location"] = *req.Location
+	}
+	if req.RegionName != nil {
+		regionName := strings.TrimSpace(*req.RegionName)
+		if err := validateRegionName(regionName); err != nil {
+			return nil, err
+		}
+		updates["region_name"] = regionName
+	}
+
+	if len(updates) == 0 {
+		return user, nil
+	}
+
+	updated, err := s.userRepo.UpdateProfile(ctx, user.ID, updates, nicknameChangeableBefore)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to update profile", err)
+	}
+	if !updated {
+		// 동시에 들어온 다른 요청이 먼저 닉네임을 바꿈
+		return nil, nicknameCooldownError(config.AppConfig.NicknameChangeCooldown)
+	}
+
+	updatedUser, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, nicknameChanged := updates["nickname"]
+	_, profileURIChanged := updates["profile_uri"]
+	if nicknameChanged || profileURIChanged {
+		s.profiles.UpdateUserProfile(userID, updatedUser.Nickname, updatedUser.ProfileURI)
+	}
+
+	return updatedUser, nil
+}
+
+func nicknameCooldownError(remaining time.Duration) error {
+	days := int(math.Ceil(config.AppConfig.NicknameChangeCooldown.Hours() / 24))
+	return apperr.TooManyRequests(fmt.Sprintf("nickname can only be changed once every %d days", days), nil).
+		WithHeader("Retry-After", strconv.Itoa(int(math.Ceil(remaining.Seconds()))))
+}
+
+// 온보딩(SetProfileRequest)과 수정(UpdateProfileRequest)에 같은 규칙을 씀
+func validateProfile(req models.SetProfileRequest) error {
+	validators := []error{
+		validateNickname(strings.TrimSpace(req.Nickname)),
+		validateProfileURI(req.ProfileURI),
+		validateAge(req.Age),
+		validateGender(req.Gender),
+		validateLocation(req.Location),
+		validateRegionName(strings.TrimSpace(req.RegionName)),
+	}
+	for _, err := range validators {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNickname(nickname string) error {
+	if n := utf8.RuneCountInString(nickname); n < 2 || n > 12 {
+		return apperr.BadRequest("nickname must be between 2 and 12 characters", nil)
+	}
+	return nil
+}
+
+func validateProfileURI(uri string) error {
+	if uri == "" || len(uri) > 2048 {
+		return apperr.BadRequest("invalid profile URI", nil)
+	}
+	return nil
+}
+
+func validateAge(age int) error {
+	if age < 14 || age > 100 {
+		return apperr.BadRequest("age must be between 14 and 100", nil)
+	}
+	return nil
+}
+
+func validateGender(gender string) error {
+	if gender != models.GenderMale && gender != models.GenderFemale {
+		return apperr.BadRequest("gender must be MALE or FEMALE", nil)
+	}
+	return nil
+}
+
+// GeoJSON Point, [경도, 위도] 순서
+func validateLocation(location models.Location) error {
+	if location.Type != "Point" || len(location.Coordinates) != 2 {
+		return apperr.BadRequest("location must be a GeoJSON Point", nil)
+	}
+	lon, lat := location.Coordinates[0], location.Coordinates[1]
+	if lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+		return apperr.BadRequest("location coordinates out of range", nil)
+	}
+	return nil
+}
+
+func validateRegionName(regionName string) error {
+	if n := utf8.RuneCountInString(regionName); n == 0 || n > 50 {
+		return apperr.BadRequest("region name must be between 1 and 50 characters", nil)
+	}
+	return nil
+}
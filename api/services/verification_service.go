@@ -0,0 +1,300 @@
+// api/services/verification_service.go
+
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/mail"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"github.com/seojoonrp/bbiyong-backend/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	emailCodeDigits      = 6
+	maxEmailCodeAttempts = 5
+)
+
+// 로컬 계정의 이메일 인증과 비밀번호 재설정
+type VerificationService interface {
+	SendEmailVerification(ctx context.Context, userID string) error
+	ChangeEmail(ctx context.Context, userID, email string) error
+	VerifyEmail(ctx context.Context, userID, code string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type verificationService struct {
+	userRepo         repositories.UserRepository
+	verificationRepo repositories.VerificationTokenRepository
+	sessionService   SessionService
+	loginThrottle    LoginThrottleService
+	mailer           mail.Mailer
+}
+
+func NewVerificationService(ur repositories.UserRepository, vr repositories.VerificationTokenRepository, ss SessionService, lts LoginThrottleService, mailer mail.Mailer) VerificationService {
+	return &verificationService{
+		userRepo:         ur,
+		verificationRepo: vr,
+		sessionService:   ss,
+		loginThrottle:    lts,
+		mailer:           mailer,
+	}
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (s *verificationService) SendEmailVerification(ctx context.Context, userID string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return apperr.BadRequest("no email registered", nil)
+	}
+	if user.EmailVerified {
+		return apperr.Conflict("email already verified", nil)
+	}
+
+	return s.sendEmailCode(ctx, user)
+}
+
+func (s *verificationService) ChangeEmail(ctx context.Context, userID, email string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	email = NormalizeEmail(email)
+	if user.Email == email {
+		if user.EmailVerified {
+			return nil
+		}
+		return s.sendEmailCode(ctx, user)
+	}
+
+	existing, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch user by email", err)
+	}
+	if existing != nil {
+		return apperr.Conflict("email already in use", nil)
+	}
+
+	if err := s.userRepo.SetEmail(ctx, user.ID, email); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return apperr.Conflict("email already in use", err)
+		}
+		return apperr.InternalServerError("failed to update email", err)
+	}
+
+	user.Email = email
+	user.EmailVerified = false
+	return s.sendEmailCode(ctx, user)
+}
+
+// 가장 최근에 보낸 코드만 유효. 틀린 횟수가 쌓이면 새 코드를 받아야 함
+func (s *verificationService) VerifyEmail(ctx context.Context, userID, code string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	token, err := s.verificationRepo.FindLatest(ctx, user.ID, models.VerificationPurposeEmail)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch verification code", err)
+	}
+	if token == nil || token.UsedAt != nil || time.Now().After(token.ExpiresAt) || token.Email != user.Email {
+		return apperr.BadRequest("verification code expired, request a new one", nil)
+	}
+	if token.Attempts >= maxEmailCodeAttempts {
+		return apperr.TooManyRequests("too many wrong codes, request a new one", nil)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashToken(strings.TrimSpace(code))), []byte(token.TokenHash)) != 1 {
+		if err := s.verificationRepo.IncrementAttempts(ctx, token.ID); err != nil {
+			return apperr.InternalServerError("failed to record verification attempt", err)
+		}
+		return apperr.BadRequest("invalid verification code", nil)
+	}
+
+	used, err := s.verificationRepo.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return apperr.InternalServerError("failed to consume verification code", err)
+	}
+	if !used {
+		return apperr.BadRequest("verification code expired, request a new one", nil)
+	}
+
+	verified, err := s.userRepo.MarkEmailVerified(ctx, user.ID, token.Email)
+	if err != nil {
+		return apperr.InternalServerError("failed to verify email", err)
+	}
+	if !verified {
+		return apperr.Conflict("email changed while verifying", nil)
+	}
+	return nil
+}
+
+// 가입 여부가 드러나지 않도록 대상이 없어도 항상 성공으로 응답함
+func (s *verificationService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, NormalizeEmail(email))
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch user by email", err)
+	}
+	// 인증 안 된 주소로 보내면 남의 계정을 가져갈 수 있음
+	if user == nil || !user.EmailVerified || user.Password == "" {
+		return nil
+	}
+
+	if s.inCooldown(ctx, user.ID, models.VerificationPurposePasswordReset) {
+		return nil
+	}
+
+	raw, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return apperr.InternalServerError("failed to generate reset token", err)
+	}
+	if err := s.issue(ctx, user, models.VerificationPurposePasswordReset, raw, config.AppConfig.PasswordResetTTL); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("비밀번호 재설정 토큰: %s\n%s 후에 만료됩니다.", raw, config.AppConfig.PasswordResetTTL)
+	if base := config.AppConfig.PasswordResetURL; base != "" {
+		body = fmt.Sprintf("아래 링크에서 비밀번호를 재설정하세요.\n%s?token=%s\n\n%s 후에 만료됩니다.", base, raw, config.AppConfig.PasswordResetTTL)
+	}
+
+	return s.send(ctx, mail.Message{To: user.Email, Subject: "[삐용] 비밀번호 재설정", Body: body})
+}
+
+// 재설정하면 기존 로그인은 모두 끊고 로그인 잠금도 풀어줌
+func (s *verificationService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	stored, err := s.verificationRepo.FindByHash(ctx, models.VerificationPurposePasswordReset, utils.HashToken(token))
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch reset token", err)
+	}
+	if stored == nil {
+		return apperr.BadRequest("invalid or expired reset token", nil)
+	}
+
+	used, err := s.verificationRepo.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return apperr.InternalServerError("failed to consume reset token", err)
+	}
+	if !used {
+		return apperr.BadRequest("invalid or expired reset token", nil)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return apperr.InternalServerError("failed to fetch user by id", err)
+	}
+	if user == nil || user.Password == "" {
+		return apperr.BadRequest("invalid or expired reset token", nil)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), 10)
+	if err != nil {
+		return apperr.InternalServerError("failed to hash password", err)
+	}
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+		return apperr.InternalServerError("failed to update password", err)
+	}
+
+	if err := s.sessionService.RevokeAllSessions(ctx, user.ID.Hex()); err != nil {
+		return err
+	}
+	s.loginThrottle.RecordSuccess(ctx, user.Username)
+
+	return nil
+}
+
+func (s *verificationService) sendEmailCode(ctx context.Context, user *models.User) error {
+	if s.inCooldown(ctx, user.ID, models.VerificationPurposeEmail) {
+		return apperr.TooManyRequests("verification code was sent recently, try again later", nil)
+	}
+
+	code, err := utils.GenerateNumericCode(emailCodeDigits)
+	if err != nil {
+		return apperr.InternalServerError("failed to generate verification code", err)
+	}
+	if err := s.issue(ctx, user, models.VerificationPurposeEmail, code, config.AppConfig.EmailVerificationTTL); err != nil {
+		return err
+	}
+
+	return s.send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "[삐용] 이메일 인증 코드",
+		Body:    fmt.Sprintf("인증 코드: %s\n%s 후에 만료됩니다.", code, config.AppConfig.EmailVerificationTTL),
+	})
+}
+
+// 같은 용도로 이전에 보낸 것은 모두 무효화하고 새로 발급
+func (s *verificationService) issue(ctx context.Context, user *models.User, purpose, raw string, ttl time.Duration) error {
+	if err := s.verificationRepo.InvalidateAll(ctx, user.ID, purpose); err != nil {
+		return apperr.InternalServerError("failed to invalidate previous tokens", err)
+	}
+
+	now := time.Now()
+	err := s.verificationRepo.Create(ctx, &models.VerificationToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(raw),
+		Email:     user.Email,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return apperr.InternalServerError("failed to save verification token", err)
+	}
+	return nil
+}
+
+// 메일 폭탄 방지. 조회 실패 시에는 막지 않음
+func (s *verificationService) inCooldown(ctx context.Context, userID primitive.ObjectID, purpose string) bool {
+	latest, err := s.verificationRepo.FindLatest(ctx, userID, purpose)
+	if err != nil {
+		log.Printf("Failed to check verification cooldown for %s: %v", userID.Hex(), err)
+		return false
+	}
+	return latest != nil && time.Since(latest.CreatedAt) < config.AppConfig.VerificationCooldown
+}
+
+func (s *verificationService) send(ctx context.Context, msg mail.Message) error {
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return apperr.ServiceUnavailable("failed to send email", err)
+	}
+	return nil
+}
+
+func (s *verificationService) getUser(ctx context.Context, userID string) (*models.User, error) {
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, uID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch user by id", err)
+	}
+	if user == nil {
+		return nil, apperr.NotFound("user not found", nil)
+	}
+	return user, nil
+}
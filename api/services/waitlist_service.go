@@ -0,0 +1,297 @@
+// api/services/waitlist_service.go
+
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/apperr"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WaitlistService interface {
+	// 대기열에 들어감. 그 사이 자리가 나서 바로 참여됐으면 nil을 돌려줌
+	Join(ctx context.Context, meetingID, userID string) (*models.WaitlistPosition, error)
+	Leave(ctx context.Context, meetingID, userID string) error
+	GetPosition(ctx context.Context, meetingID, userID string) (*models.WaitlistPosition, error)
+	// 대기열에 없으면 nil
+	FindPosition(ctx context.Context, meetingID, userID primitive.ObjectID) (*models.WaitlistPosition, error)
+	// 빈자리만큼 대기열 앞사람부터 참여시킴. 참여자가 빠지거나 정원이 늘면 호출
+	Promote(ctx context.Context, meetingID primitive.ObjectID) error
+	RemoveUser(ctx context.Context, userID primitive.ObjectID) error
+	// 모임이 취소되거나 승인제로 바뀌었을 때 대기열을 비움. 빠진 유저를 돌려줌
+	Clear(ctx context.Context, meetingID primitive.ObjectID) ([]primitive.ObjectID, error)
+}
+
+type waitlistService struct {
+	waitlistRepo  repositories.WaitlistRepository
+	meetingRepo   repositories.MeetingRepository
+	userRepo      repositories.UserRepository
+	notifications NotificationService
+	eventChan     chan<- models.MeetingEvent
+}
+
+func NewWaitlistService(wr repositories.WaitlistRepository, mr repositories.MeetingRepository, ur repositories.UserRepository, ns NotificationService, ec chan<- models.MeetingEvent) WaitlistService {
+	return &waitlistService{waitlistRepo: wr, meetingRepo: mr, userRepo: ur, notifications: ns, eventChan: ec}
+}
+
+func (s *waitlistService) Join(ctx context.Context, meetingID, userID string) (*models.WaitlistPosition, error) {
+	meeting, uID, err := s.loadMeeting(ctx, meetingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 승인제 모임은 대기열로 들어오면 승인을 건너뛰게 되므로 막음
+	if meeting.EffectiveJoinMode() != models.JoinModeInstant {
+		return nil, apperr.BadRequest("waitlist is only available for instant join meetings", nil)
+	}
+	// 끝난 모임의 대기열은 아무도 비워 주지 않음
+	if !meeting.IsActive() {
+		return nil, joinBlockedError(models.JoinBlockedClosed)
+	}
+	if containsID(meeting.ParticipantIDs, uID) {
+		return nil, apperr.BadRequest("already joined the meeting", nil)
+	}
+
+	user, err := findUser(ctx, s.userRepo, uID)
+	if err != nil {
+		return nil, err
+	}
+	if reason := eligibilityReason(meeting, user); reason != "" {
+		return nil, joinBlockedError(reason)
+	}
+	// 내 성별 자리만 찬 경우에도 줄 설 수 있음
+	if reason := joinBlockedReason(meeting, user); reason != models.JoinBlockedFull && reason != models.JoinBlockedGenderFull {
+		return nil, apperr.BadRequest("waitlist is only available when the meeting is full", nil)
+	}
+
+	err = s.waitlistRepo.Create(ctx, &models.WaitlistEntry{
+		MeetingID: meeting.ID,
+		UserID:    uID,
+		Gender:    user.Gender,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperr.Conflict("already on the waitlist", err)
+		}
+		return nil, apperr.InternalServerError("failed to join waitlist", err)
+	}
+
+	if err := s.meetingRepo.IncrementWaitlistCount(ctx, meeting.ID, user.Gender, 1); err != nil {
+		return nil, apperr.InternalServerError("failed to update waitlist count", err)
+	}
+
+	// 확인한 뒤에 자리가 났을 수도 있음
+	if err := s.Promote(ctx, meeting.ID); err != nil {
+		log.Printf("Failed to promote waitlist of meeting %s: %v", meetingID, err)
+	}
+
+	return s.FindPosition(ctx, meeting.ID, uID)
+}
+
+func (s *waitlistService) Leave(ctx context.Context, meetingID, userID string) error {
+	mID, err := primitive.ObjectIDFromHex(meetingID)
+	if err != nil {
+		return apperr.BadRequest("invalid meeting ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	entry, err := s.waitlistRepo.Delete(ctx, mID, uID)
+	if err != nil {
+		return apperr.InternalServerError("failed to leave waitlist", err)
+	}
+	if entry == nil {
+		return apperr.NotFound("not on the waitlist", nil)
+	}
+
+	if err := s.meetingRepo.IncrementWaitlistCount(ctx, mID, entry.Gender, -1); err != nil {
+		return apperr.InternalServerError("failed to update waitlist count", err)
+	}
+
+	// 내가 마지막 대기자였다면 막혀 있던 빈자리가 풀림
+	if err := s.Promote(ctx, mID); err != nil {
+		log.Printf("Failed to promote waitlist of meeting %s: %v", meetingID, err)
+	}
+	return nil
+}
+
+func (s *waitlistService) GetPosition(ctx context.Context, meetingID, userID string) (*models.WaitlistPosition, error) {
+	mID, err := primitive.ObjectIDFromHex(meetingID)
+	if err != nil {
+		return nil, apperr.BadRequest("invalid meeting ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	position, err := s.FindPosition(ctx, mID, uID)
+	if err != nil {
+		return nil, err
+	}
+	if position == nil {
+		return nil, apperr.NotFound("not on the waitlist", nil)
+	}
+	return position, nil
+}
+
+// 한 명씩 꺼내서 참여시킴. 자리가 없으면 꺼낸 사람을 원래 자리로 되돌리고 멈춤
+func (s *waitlistService) Promote(ctx context.Context, meetingID primitive.ObjectID) error {
+	for {
+		meeting, err := s.meetingRepo.FindByID(ctx, meetingID)
+		if err != nil || meeting == nil {
+			return err
+		}
+		// 승인제로 바뀐 모임은 대기열로 승인을 건너뛰면 안 됨
+		if meeting.EffectiveJoinMode() != models.JoinModeInstant {
+			return nil
+		}
+
+		// 성별 정원이 있으면 자리가 남은 성별의 대기자만 올릴 수 있음
+		var genders []string
+		if meeting.GenderQuota != nil {
+			genders = []string{}
+			for _, g := range []string{models.GenderMale, models.GenderFemale} {
+				if meeting.AllowsGender(g) && !meeting.IsGenderFull(g) {
+					genders = append(genders, g)
+				}
+			}
+		}
+
+		entry, err := s.waitlistRepo.PopFirst(ctx, meetingID, genders)
+		if err != nil || entry == nil {
+			return err
+		}
+
+		user, err := s.userRepo.FindByID(ctx, entry.UserID)
+		if err != nil {
+			return s.restore(ctx, entry, err)
+		}
+
+		// 더 이상 참여할 수 없는 사람 (탈퇴, 이미 참여, 강퇴, 나이/성별 조건 변경, 모집 종료). 대기열에서 빼고 다음 사람으로
+		if user == nil || !meeting.IsActive() || containsID(meeting.ParticipantIDs, entry.UserID) || eligibilityReason(meeting, user) != "" {
+			if err := s.meetingRepo.IncrementWaitlistCount(ctx, meetingID, entry.Gender, -1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		promoted, err := s.meetingRepo.PromoteFromWaitlist(ctx, entry, user.Gender)
+		if err != nil {
+			return s.restore(ctx, entry, err)
+		}
+
+		if promoted {
+			s.eventChan <- models.MeetingEvent{
+				Type:      models.EventJoinMeeting,
+				MeetingID: meetingID.Hex(),
+				UserID:    entry.UserID.Hex(),
+			}
+			if err := s.notifications.NotifyWaitlistPromoted(ctx, meeting, entry.UserID); err != nil {
+				log.Printf("Failed to notify waitlist promotion of meeting %s: %v", meetingID.Hex(), err)
+			}
+			continue
+		}
+
+		// 빈자리가 없음
+		return s.restore(ctx, entry, nil)
+	}
+}
+
+// 탈퇴한 유저를 모든 대기열에서 뺌
+func (s *waitlistService) RemoveUser(ctx context.Context, userID primitive.ObjectID) error {
+	entries, err := s.waitlistRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		deleted, err := s.waitlistRepo.Delete(ctx, entry.MeetingID, userID)
+		if err != nil {
+			return err
+		}
+		if deleted == nil {
+			continue
+		}
+		if err := s.meetingRepo.IncrementWaitlistCount(ctx, entry.MeetingID, deleted.Gender, -1); err != nil {
+			return err
+		}
+		if err := s.Promote(ctx, entry.MeetingID); err != nil {
+			log.Printf("Failed to promote waitlist of meeting %s: %v", entry.MeetingID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+func (s *waitlistService) Clear(ctx context.Context, meetingID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	userIDs, err := s.waitlistRepo.DeleteAllByMeeting(ctx, meetingID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to clear waitlist", err)
+	}
+	if err := s.meetingRepo.ResetWaitlistCount(ctx, meetingID); err != nil {
+		return nil, apperr.InternalServerError("failed to reset waitlist count", err)
+	}
+	return userIDs, nil
+}
+
+func (s *waitlistService) restore(ctx context.Context, entry *models.WaitlistEntry, cause error) error {
+	if err := s.waitlistRepo.Create(ctx, entry); err != nil {
+		log.Printf("Failed to restore waitlist entry of user %s: %v", entry.UserID.Hex(), err)
+	}
+	return cause
+}
+
+func (s *waitlistService) FindPosition(ctx context.Context, meetingID, userID primitive.ObjectID) (*models.WaitlistPosition, error) {
+	entry, err := s.waitlistRepo.Find(ctx, meetingID, userID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch waitlist entry", err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	ahead, err := s.waitlistRepo.CountAhead(ctx, entry)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch waitlist position", err)
+	}
+	total, err := s.waitlistRepo.CountByMeeting(ctx, meetingID)
+	if err != nil {
+		return nil, apperr.InternalServerError("failed to fetch waitlist size", err)
+	}
+
+	return &models.WaitlistPosition{MeetingID: meetingID, Position: ahead + 1, Total: total}, nil
+}
+
+func (s *waitlistService) loadMeeting(ctx context.Context, meetingID, userID string) (*models.Meeting, primitive.ObjectID, error) {
+	var zero primitive.ObjectID
+
+	mID, err := primitive.ObjectIDFromHex(meetingID)
+	if err != nil {
+		return nil, zero, apperr.BadRequest("invalid meeting ID format", err)
+	}
+
+	uID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, zero, apperr.InternalServerError("invalid user ID in token", err)
+	}
+
+	meeting, err := s.meetingRepo.FindByID(ctx, mID)
+	if err != nil {
+		return nil, zero, apperr.InternalServerError("failed to fetch meeting", err)
+	}
+	if meeting == nil {
+		return nil, zero, apperr.NotFound("meeting not found", nil)
+	}
+	return meeting, uID, nil
+}
@@ -0,0 +1,241 @@
+// api/services/waitlist_service_test.go
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/seojoonrp/bbiyong-backend/api/repositories"
+	"github.com/seojoonrp/bbiyong-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 대기열을 created_at 순으로 들고 있음. 되돌린 항목도 원래 자리로 돌아감
+type memWaitlistRepo struct {
+	repositories.WaitlistRepository
+	entries []*models.WaitlistEntry
+}
+
+func (r *memWaitlistRepo) Create(ctx context.Context, entry *models.WaitlistEntry) error {
+	r.entries = append(r.entries, entry)
+	sort.SliceStable(r.entries, func(i, j int) bool { return r.entries[i].CreatedAt.Before(r.entries[j].CreatedAt) })
+	return nil
+}
+
+func (r *memWaitlistRepo) PopFirst(ctx context.Context, meetingID primitive.ObjectID, genders []string) (*models.WaitlistEntry, error) {
+	for i, e := range r.entries {
+		if genders != nil && !containsString(genders, e.Gender) {
+			continue
+		}
+		r.entries = append(r.entries[:i], r.entries[i+1:]...)
+		return e, nil
+	}
+	return nil, nil
+}
+
+// 대기열 승격에 쓰는 모임 저장소 메서드. 조건은 PromoteFromWaitlist의 필터와 맞춤
+func (r *memMeetingRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Meeting, error) {
+	if r.meeting == nil || r.meeting.ID != id {
+		return nil, nil
+	}
+	copied := *r.meeting
+	copied.ParticipantIDs = append([]primitive.ObjectID(nil), r.meeting.ParticipantIDs...)
+	copied.ParticipantGenders = append([]models.ParticipantGender(nil), r.meeting.ParticipantGenders...)
+	return &copied, nil
+}
+
+func (r *memMeetingRepo) IncrementWaitlistCount(ctx context.Context, meetingID primitive.ObjectID, gender string, delta int) error {
+	r.meeting.WaitlistCount += delta
+	return nil
+}
+
+func (r *memMeetingRepo) PromoteFromWaitlist(ctx context.Context, entry *models.WaitlistEntry, gender string) (bool, error) {
+	m := r.meeting
+	if m.Status != models.MeetingStatusRecruiting || len(m.ParticipantIDs) >= m.MaxParticipants || m.IsGenderFull(gender) || m.IsBanned(entry.UserID) {
+		return false, nil
+	}
+	m.ParticipantIDs = append(m.ParticipantIDs, entry.UserID)
+	m.ParticipantGenders = append(m.ParticipantGenders, models.ParticipantGender{UserID: entry.UserID, Gender: gender})
+	m.WaitlistCount--
+	if len(m.ParticipantIDs) >= m.MaxParticipants {
+		m.Status = models.MeetingStatusFull
+	}
+	return true, nil
+}
+
+type stubNotificationService struct {
+	NotificationService
+}
+
+func (stubNotificationService) NotifyWaitlistPromoted(ctx context.Context, meeting *models.Meeting, userID primitive.ObjectID) error {
+	return nil
+}
+
+func TestPromote(t *testing.T) {
+	hostID := primitive.NewObjectID()
+	male1, male2 := primitive.NewObjectID(), primitive.NewObjectID()
+	female1, female2 := primitive.NewObjectID(), primitive.NewObjectID()
+	banned := primitive.NewObjectID()
+
+	users := newMemUserRepo(
+		&models.User{ID: hostID, Gender: models.GenderFemale, Age: 25},
+		&models.User{ID: male1, Gender: models.GenderMale, Age: 25},
+		&models.User{ID: male2, Gender: models.GenderMale, Age: 25},
+		&models.User{ID: female1, Gender: models.GenderFemale, Age: 25},
+		&models.User{ID: female2, Gender: models.GenderFemale, Age: 25},
+		&models.User{ID: banned, Gender: models.GenderMale, Age: 25},
+	)
+
+	tests := []struct {
+		name         string
+		joinMode     string
+		max          int
+		quota        *models.GenderQuota
+		bannedIDs    []primitive.ObjectID
+		waiting      []primitive.ObjectID // 줄 선 순서
+		wantPromoted []primitive.ObjectID
+		wantWaiting  []primitive.ObjectID
+	}{
+		{
+			name:         "fills open slots in order",
+			max:          3,
+			waiting:      []primitive.ObjectID{male1, female1, male2},
+			wantPromoted: []primitive.ObjectID{male1, female1},
+			wantWaiting:  []primitive.ObjectID{male2},
+		},
+		{
+			name:         "approval meeting keeps the queue",
+			joinMode:     models.JoinModeApproval,
+			max:          3,
+			waiting:      []primitive.ObjectID{male1, female1},
+			wantPromoted: nil,
+			wantWaiting:  []primitive.ObjectID{male1, female1},
+		},
+		{
+			name:         "skips waiters whose gender is full",
+			max:          3,
+			quota:        &models.GenderQuota{Male: 1, Female: 1},
+			waiting:      []primitive.ObjectID{female1, male1, female2},
+			wantPromoted: []primitive.ObjectID{male1},
+			wantWaiting:  []primitive.ObjectID{female1, female2},
+		},
+		{
+			name:         "all genders full",
+			max:          4,
+			quota:        &models.GenderQuota{Male: 0, Female: 1},
+			waiting:      []primitive.ObjectID{female1},
+			wantPromoted: nil,
+			wantWaiting:  []primitive.ObjectID{female1},
+		},
+		{
+			name:         "drops ineligible waiter and moves on",
+			max:          2,
+			bannedIDs:    []primitive.ObjectID{banned},
+			waiting:      []primitive.ObjectID{banned, male1, male2},
+			wantPromoted: []primitive.ObjectID{male1},
+			wantWaiting:  []primitive.ObjectID{male2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meeting := &models.Meeting{
+				ID:                 primitive.NewObjectID(),
+				HostID:             hostID,
+				Status:             models.MeetingStatusRecruiting,
+				JoinMode:           tt.joinMode,
+				MaxParticipants:    tt.max,
+				GenderQuota:        tt.quota,
+				BannedIDs:          tt.bannedIDs,
+				ParticipantIDs:     []primitive.ObjectID{hostID},
+				ParticipantGenders: []models.ParticipantGender{{UserID: hostID, Gender: models.GenderFemale}},
+				WaitlistCount:      len(tt.waiting),
+			}
+
+			waitlist := &memWaitlistRepo{}
+			base := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+			for i, id := range tt.waiting {
+				user, _ := users.FindByID(context.Background(), id)
+				waitlist.Create(context.Background(), &models.WaitlistEntry{
+					MeetingID: meeting.ID,
+					UserID:    id,
+					Gender:    user.Gender,
+					CreatedAt: base.Add(time.Duration(i) * time.Minute),
+				})
+			}
+
+			events := make(chan models.MeetingEvent, len(tt.waiting))
+			s := NewWaitlistService(waitlist, &memMeetingRepo{meeting: meeting}, users, stubNotificationService{}, events)
+
+			if err := s.Promote(context.Background(), meeting.ID); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			close(events)
+
+			var promoted []primitive.ObjectID
+			for e := range events {
+				id, _ := primitive.ObjectIDFromHex(e.UserID)
+				promoted = append(promoted, id)
+			}
+			if !equalIDs(promoted, tt.wantPromoted) {
+				t.Errorf("promoted = %v, want %v", promoted, tt.wantPromoted)
+			}
+			if !equalIDs(meeting.ParticipantIDs[1:], tt.wantPromoted) {
+				t.Errorf("participants = %v, want host + %v", meeting.ParticipantIDs, tt.wantPromoted)
+			}
+
+			var waiting []primitive.ObjectID
+			for _, e := range waitlist.entries {
+				waiting = append(waiting, e.UserID)
+			}
+			if !equalIDs(waiting, tt.wantWaiting) {
+				t.Errorf("waiting = %v, want %v", waiting, tt.wantWaiting)
+			}
+			if meeting.WaitlistCount != len(tt.wantWaiting) {
+				t.Errorf("waitlist count = %d, want %d", meeting.WaitlistCount, len(tt.wantWaiting))
+			}
+		})
+	}
+}
+
+func equalIDs(a, b []primitive.ObjectID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestJoinWaitlistRejectsEndedMeeting(t *testing.T) {
+	user := &models.User{ID: primitive.NewObjectID(), Gender: models.GenderMale, Age: 25}
+
+	for _, status := range []string{models.MeetingStatusOngoing, models.MeetingStatusFinished, models.MeetingStatusCancelled} {
+		t.Run(status, func(t *testing.T) {
+			// 정원이 다 찬 채로 끝난 모임
+			meeting := &models.Meeting{
+				ID:              primitive.NewObjectID(),
+				Status:          status,
+				MaxParticipants: 1,
+				ParticipantIDs:  []primitive.ObjectID{primitive.NewObjectID()},
+			}
+			waitlist := &memWaitlistRepo{}
+			s := NewWaitlistService(waitlist, &memMeetingRepo{meeting: meeting}, newMemUserRepo(user), stubNotificationService{}, nil)
+
+			_, err := s.Join(context.Background(), meeting.ID.Hex(), user.ID.Hex())
+			if got := statusOf(err); got != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d (err %v)", got, http.StatusBadRequest, err)
+			}
+			if len(waitlist.entries) != 0 {
+				t.Errorf("waitlist entry created for %s meeting", status)
+			}
+		})
+	}
+}
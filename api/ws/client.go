@@ -5,6 +5,7 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"sync"
 
 	"github.com/gorilla/websocket"
 	"github.com/seojoonrp/bbiyong-backend/api/services"
@@ -21,6 +22,49 @@ type Client struct {
 	ChatService      services.ChatService
 	Ctx              context.Context
 	Cancel           context.CancelFunc
+
+	mu sync.RWMutex // 허브가 프로필 변경을 반영할 때 SenderName/SenderProfileURI 보호
+
+	sendMu sync.Mutex // 허브가 Send를 닫는 것과 ReadPump가 에러를 보내는 것이 겹치지 않게 함
+	closed bool
+}
+
+// Send는 항상 여기서만 닫음. 강퇴나 탈퇴로 허브가 닫은 뒤에 ReadPump가 보내면 패닉이 나기 때문
+func (c *Client) closeSend() {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.Send)
+}
+
+// 닫혔거나 버퍼가 차 있으면 버림. 락을 잡은 채 기다리면 허브가 closeSend에서 멈춤
+func (c *Client) trySend(data []byte) {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.Send <- data:
+	default:
+	}
+}
+
+// 접속 중에 프로필을 바꾸면 허브가 호출함. 이후 보내는 메시지부터 새 이름이 들어감
+func (c *Client) SetProfile(name, profileURI string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.SenderName = name
+	c.SenderProfileURI = profileURI
+}
+
+func (c *Client) Profile() (string, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.SenderName, c.SenderProfileURI
 }
 
 // 메시지를 읽어서 허브로 보냄
@@ -36,7 +80,8 @@ func (c *Client) ReadPump() {
 			break // 연결이 뭔가 이상해졌을 때
 		}
 
-		savedMsg, err := c.ChatService.SaveMessage(context.Background(), c.MeetingID, c.UserID, string(message), c.SenderName, c.SenderProfileURI)
+		name, profileURI := c.Profile()
+		savedMsg, err := c.ChatService.SaveMessage(context.Background(), c.MeetingID, c.UserID, string(message), name, profileURI)
 		if err != nil {
 			c.sendError("Failed to save message:" + err.Error())
 			continue
@@ -78,5 +123,5 @@ func (c *Client) sendError(msg string) {
 		Type:    "ERROR",
 		Message: msg,
 	})
-	c.Send <- errPayload
+	c.trySend(errPayload)
 }
@@ -0,0 +1,40 @@
+// api/ws/client_test.go
+
+package ws
+
+import (
+	"sync"
+	"testing"
+)
+
+// 강퇴로 허브가 Send를 닫는 동안 ReadPump가 에러를 보내도 패닉이 나면 안 됨
+func TestSendAfterCloseDoesNotPanic(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		c := &Client{Send: make(chan []byte, 1)}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				c.sendError("boom")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			c.closeSend()
+			c.closeSend()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestTrySendDropsWhenFull(t *testing.T) {
+	c := &Client{Send: make(chan []byte, 1)}
+	c.trySend([]byte("a"))
+	c.trySend([]byte("b")) // 버퍼가 차 있으면 기다리지 않고 버림
+
+	if got := string(<-c.Send); got != "a" {
+		t.Fatalf("got %q, want a", got)
+	}
+}
@@ -11,6 +11,8 @@ type Hub struct {
 	Broadcast  chan MessagePayload         // 메시지 전달 채널
 	Register   chan *Client                // 나 여기 들어간다
 	Unregister chan *Client                // 나 나간다
+	Disconnect chan DisconnectRequest      // 서버가 강제로 연결 끊기
+	Profile    chan ProfileUpdate          // 접속 중인 유저의 프로필 변경
 	mu         sync.Mutex
 }
 
@@ -19,15 +21,43 @@ type MessagePayload struct {
 	Data      []byte // JSON-encoded
 }
 
+// MeetingID가 비어 있으면 해당 유저의 모든 방 연결을 끊음
+type DisconnectRequest struct {
+	UserID    string
+	MeetingID string
+}
+
+type ProfileUpdate struct {
+	UserID     string
+	Nickname   string
+	ProfileURI string
+}
+
 func NewHub() *Hub {
 	return &Hub{
 		Rooms:      make(map[string]map[*Client]bool),
 		Broadcast:  make(chan MessagePayload),
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
+		Disconnect: make(chan DisconnectRequest),
+		Profile:    make(chan ProfileUpdate),
 	}
 }
 
+// 탈퇴 등으로 유저의 웹소켓 연결을 전부 끊을 때
+func (h *Hub) DisconnectUser(userID string) {
+	h.Disconnect <- DisconnectRequest{UserID: userID}
+}
+
+// 강퇴당한 유저의 해당 방 연결만 끊을 때
+func (h *Hub) DisconnectUserFromMeeting(userID, meetingID string) {
+	h.Disconnect <- DisconnectRequest{UserID: userID, MeetingID: meetingID}
+}
+
+func (h *Hub) UpdateUserProfile(userID, nickname, profileURI string) {
+	h.Profile <- ProfileUpdate{UserID: userID, Nickname: nickname, ProfileURI: profileURI}
+}
+
 // 무한루프 돌면서 브로드캐스팅 처리
 func (h *Hub) Run() {
 	for {
@@ -45,7 +75,7 @@ func (h *Hub) Run() {
 			if clients, ok := h.Rooms[client.MeetingID]; ok {
 				if _, ok := clients[client]; ok {
 					delete(clients, client)
-					close(client.Send)
+					client.closeSend()
 					if len(clients) == 0 {
 						delete(h.Rooms, client.MeetingID)
 					}
@@ -53,6 +83,36 @@ func (h *Hub) Run() {
 			}
 			h.mu.Unlock()
 
+		case req := <-h.Disconnect:
+			// Send를 닫으면 WritePump가 close 프레임을 보내고 연결을 정리함
+			h.mu.Lock()
+			for meetingID, clients := range h.Rooms {
+				if req.MeetingID != "" && req.MeetingID != meetingID {
+					continue
+				}
+				for client := range clients {
+					if client.UserID == req.UserID {
+						client.closeSend()
+						delete(clients, client)
+					}
+				}
+				if len(clients) == 0 {
+					delete(h.Rooms, meetingID)
+				}
+			}
+			h.mu.Unlock()
+
+		case update := <-h.Profile:
+			h.mu.Lock()
+			for _, clients := range h.Rooms {
+				for client := range clients {
+					if client.UserID == update.UserID {
+						client.SetProfile(update.Nickname, update.ProfileURI)
+					}
+				}
+			}
+			h.mu.Unlock()
+
 		case payload := <-h.Broadcast:
 			h.mu.Lock()
 			clients := h.Rooms[payload.MeetingID]
@@ -60,7 +120,7 @@ func (h *Hub) Run() {
 				select {
 				case client.Send <- payload.Data:
 				default: // 인터넷 연결 불안정 등으로 전송이 안될 때 -> 넌 나가라!
-					close(client.Send)
+					client.closeSend()
 					delete(clients, client)
 				}
 			}
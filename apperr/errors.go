@@ -5,15 +5,42 @@ package apperr
 import "net/http"
 
 type AppError struct {
-	StatusCode int    `json:"-"`
-	Message    string `json:"message"`
-	Raw        error  `json:"-"`
+	StatusCode int               `json:"-"`
+	Message    string            `json:"message"`
+	Raw        error             `json:"-"`
+	Headers    map[string]string `json:"-"`
+	Code       string            `json:"code,omitempty"`
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
+// 응답에 함께 실을 헤더 (Retry-After 등)
+func (e *AppError) WithHeader(key, value string) *AppError {
+	if e.Headers == nil {
+		e.Headers = make(map[string]string)
+	}
+	e.Headers[key] = value
+	return e
+}
+
+// 클라이언트가 분기할 때 쓰는 고정 에러 코드
+const (
+	CodeProfileIncomplete = "PROFILE_INCOMPLETE" // 온보딩 화면으로 보냄
+	CodeDeletionPending   = "DELETION_PENDING"   // 탈퇴 취소(복구) 화면으로 보냄
+	CodeBannedFromMeeting = "BANNED_FROM_MEETING"
+	CodeMeetingFull       = "MEETING_FULL" // 대기열 안내
+	CodeAgeNotAllowed     = "AGE_NOT_ALLOWED"
+	CodeGenderNotAllowed  = "GENDER_NOT_ALLOWED"
+	CodeGenderQuotaFull   = "GENDER_QUOTA_FULL"
+)
+
+func (e *AppError) WithCode(code string) *AppError {
+	e.Code = code
+	return e
+}
+
 func New(code int, msg string, raw error) *AppError {
 	return &AppError{
 		StatusCode: code,
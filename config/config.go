@@ -3,19 +3,67 @@
 package config
 
 import (
+	"encoding/base64"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port              string
-	MongoURI          string
-	DBName            string
-	JWTSecret         string
-	GoogleWebClientID string
-	AppleBundleID     string
+	AppEnv                   string
+	Port                     string
+	MongoURI                 string
+	DBName                   string
+	JWTSecret                string
+	JWTIssuer                string
+	JWTSigningAlgorithm      string
+	JWTKeyRotationPeriod     time.Duration
+	JWTKeyPublishLead        time.Duration
+	JWTKeyCheckInterval      time.Duration
+	JWTLegacyHS256Until      time.Time
+	DataEncryptionKey        []byte
+	AccessTokenTTL           time.Duration
+	RefreshTokenTTL          time.Duration
+	RevocationSyncInterval   time.Duration
+	GoogleWebClientID        string
+	AppleBundleID            string
+	AppleIssuer              string
+	AppleJWKSURL             string
+	KakaoUserInfoURL         string
+	JWKSRefreshInterval      time.Duration
+	JWKSUnknownKIDWindow     time.Duration
+	AccountDeletionGraceDays int
+	AccountPurgeInterval     time.Duration
+	LoginAttemptStore        string
+	LoginMaxFailuresPerUser  int
+	LoginMaxFailuresPerIP    int
+	LoginFailureWindow       time.Duration
+	LoginLockoutBase         time.Duration
+	LoginLockoutMax          time.Duration
+	TrustedProxies           []string
+	AdminBootstrapUsernames  []string
+	MailMode                 string
+	MailFileDir              string
+	MailFrom                 string
+	SMTPHost                 string
+	SMTPPort                 int
+	SMTPUsername             string
+	SMTPPassword             string
+	EmailVerificationTTL     time.Duration
+	PasswordResetTTL         time.Duration
+	PasswordResetURL         string
+	VerificationCooldown     time.Duration
+	TwoFactorIssuer          string
+	TwoFactorChallengeTTL    time.Duration
+	NicknameChangeCooldown   time.Duration
+	MeetingDuration          time.Duration
+	MeetingLifecycleInterval time.Duration
+	SeriesLookahead          time.Duration
+	SeriesJobInterval        time.Duration
 }
 
 var AppConfig Config
@@ -26,18 +74,180 @@ func LoadConfig() {
 	}
 
 	AppConfig = Config{
-		Port:              getEnv("PORT", "8080"),
-		MongoURI:          getEnv("MONGO_URI", ""),
-		DBName:            getEnv("DB_NAME", "bbiyong"),
-		JWTSecret:         getEnv("JWT_SECRET", ""),
-		GoogleWebClientID: getEnv("GOOGLE_WEB_CLIENT_ID", ""),
-		AppleBundleID:     getEnv("APPLE_BUNDLE_ID", ""),
+		AppEnv:                   getEnv("APP_ENV", "production"), // "development"에서만 개발용 설정을 허용
+		Port:                     getEnv("PORT", "8080"),
+		MongoURI:                 getEnv("MONGO_URI", ""),
+		DBName:                   getEnv("DB_NAME", "bbiyong"),
+		JWTSecret:                getEnv("JWT_SECRET", ""),
+		JWTIssuer:                getEnv("JWT_ISSUER", "bbiyong"),
+		JWTSigningAlgorithm:      getEnv("JWT_SIGNING_ALG", "ES256"),
+		JWTKeyRotationPeriod:     getEnvDuration("JWT_KEY_ROTATION_PERIOD", 30*24*time.Hour),
+		JWTKeyPublishLead:        getEnvDuration("JWT_KEY_PUBLISH_LEAD", 24*time.Hour),
+		JWTKeyCheckInterval:      getEnvDuration("JWT_KEY_CHECK_INTERVAL", 5*time.Minute),
+		JWTLegacyHS256Until:      getEnvTime("JWT_LEGACY_HS256_UNTIL"), // 이 시각까지만 기존 HS256 토큰을 받아줌
+		DataEncryptionKey:        getEnvKey("DATA_ENCRYPTION_KEY"),
+		AccessTokenTTL:           getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:          getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		RevocationSyncInterval:   getEnvDuration("REVOCATION_SYNC_INTERVAL", 30*time.Second),
+		GoogleWebClientID:        getEnv("GOOGLE_WEB_CLIENT_ID", ""),
+		AppleBundleID:            getEnv("APPLE_BUNDLE_ID", ""),
+		AppleIssuer:              getEnv("APPLE_ISSUER", "https://appleid.apple.com"),
+		AppleJWKSURL:             getEnv("APPLE_JWKS_URL", "https://appleid.apple.com/auth/keys"),
+		KakaoUserInfoURL:         getEnv("KAKAO_USER_INFO_URL", "https://kapi.kakao.com/v2/user/me"),
+		JWKSRefreshInterval:      getEnvDuration("JWKS_REFRESH_INTERVAL", time.Hour),
+		JWKSUnknownKIDWindow:     getEnvDuration("JWKS_UNKNOWN_KID_WINDOW", 5*time.Minute),
+		AccountDeletionGraceDays: getEnvInt("ACCOUNT_DELETION_GRACE_DAYS", 14),
+		AccountPurgeInterval:     getEnvDuration("ACCOUNT_PURGE_INTERVAL", time.Hour),
+		LoginAttemptStore:        getEnv("LOGIN_ATTEMPT_STORE", "mongo"), // 인스턴스가 하나뿐이면 "memory"
+		LoginMaxFailuresPerUser:  getEnvInt("LOGIN_MAX_FAILURES_PER_USER", 5),
+		LoginMaxFailuresPerIP:    getEnvInt("LOGIN_MAX_FAILURES_PER_IP", 30),
+		LoginFailureWindow:       getEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		LoginLockoutBase:         getEnvDuration("LOGIN_LOCKOUT_BASE", time.Minute),
+		LoginLockoutMax:          getEnvDuration("LOGIN_LOCKOUT_MAX", time.Hour),
+		// 로드 밸런서 뒤에서는 반드시 설정. 비어 있으면 X-Forwarded-For를 믿지 않아서
+		// 모든 요청이 LB 주소 하나로 잡히고, IP 실패 카운터가 잠기면 로컬 로그인이 전부 막힘
+		TrustedProxies:           getEnvList("TRUSTED_PROXIES"), // 쉼표로 구분한 IP나 CIDR
+		AdminBootstrapUsernames:  getEnvList("ADMIN_BOOTSTRAP_USERNAMES"),
+		MailMode:                 getEnv("MAIL_MODE", "smtp"), // "log" | "file" | "smtp". log와 file은 개발 환경에서만
+		MailFileDir:              getEnv("MAIL_FILE_DIR", "./tmp/mail"),
+		MailFrom:                 getEnv("MAIL_FROM", "no-reply@bbiyong.app"),
+		SMTPHost:                 getEnv("SMTP_HOST", ""),
+		SMTPPort:                 getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:             getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:             getEnv("SMTP_PASSWORD", ""),
+		EmailVerificationTTL:     getEnvDuration("EMAIL_VERIFICATION_TTL", 30*time.Minute),
+		PasswordResetTTL:         getEnvDuration("PASSWORD_RESET_TTL", 30*time.Minute),
+		PasswordResetURL:         getEnv("PASSWORD_RESET_URL", ""),
+		VerificationCooldown:     getEnvDuration("VERIFICATION_RESEND_COOLDOWN", time.Minute),
+		TwoFactorIssuer:          getEnv("TWO_FACTOR_ISSUER", "bbiyong"), // 인증 앱에 표시되는 이름
+		TwoFactorChallengeTTL:    getEnvDuration("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+		NicknameChangeCooldown:   getEnvDuration("NICKNAME_CHANGE_COOLDOWN", 30*24*time.Hour),
+		MeetingDuration:          getEnvDuration("MEETING_DURATION", 3*time.Hour), // 모임 시간부터 이만큼 지나면 종료 처리
+		MeetingLifecycleInterval: getEnvDuration("MEETING_LIFECYCLE_INTERVAL", time.Minute),
+		SeriesLookahead:          getEnvDuration("SERIES_LOOKAHEAD", 14*24*time.Hour), // 반복 모임 회차를 이만큼 앞까지 미리 만들어 둠
+		SeriesJobInterval:        getEnvDuration("SERIES_JOB_INTERVAL", 10*time.Minute),
+	}
+
+	validateConfig(&AppConfig)
+}
+
+// 잘못된 보안 설정으로 뜨느니 바로 종료
+func validateConfig(cfg *Config) {
+	if cfg.JWTSigningAlgorithm != "ES256" && cfg.JWTSigningAlgorithm != "EdDSA" {
+		log.Fatalf("JWT_SIGNING_ALG must be ES256 or EdDSA, got %q", cfg.JWTSigningAlgorithm)
+	}
+	if cfg.JWTKeyRotationPeriod <= cfg.JWTKeyPublishLead {
+		log.Fatal("JWT_KEY_ROTATION_PERIOD must be longer than JWT_KEY_PUBLISH_LEAD")
+	}
+	if time.Now().Before(cfg.JWTLegacyHS256Until) && len(cfg.JWTSecret) < 32 {
+		log.Fatal("JWT_SECRET must be at least 32 bytes while JWT_LEGACY_HS256_UNTIL is in the future")
+	}
+	if cfg.LoginAttemptStore != "mongo" && cfg.LoginAttemptStore != "memory" {
+		log.Fatalf("LOGIN_ATTEMPT_STORE must be mongo or memory, got %q", cfg.LoginAttemptStore)
+	}
+	switch cfg.MailMode {
+	case "log", "file":
+		// 인증 코드와 재설정 토큰이 그대로 남으므로 운영에서는 못 씀
+		if !cfg.IsDevelopment() {
+			log.Fatalf("MAIL_MODE=%s is only allowed with APP_ENV=development", cfg.MailMode)
+		}
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			log.Fatal("SMTP_HOST is required when MAIL_MODE=smtp")
+		}
+	default:
+		log.Fatalf("MAIL_MODE must be log, file or smtp, got %q", cfg.MailMode)
+	}
+	if cfg.MeetingDuration <= 0 || cfg.MeetingLifecycleInterval <= 0 {
+		log.Fatal("MEETING_DURATION and MEETING_LIFECYCLE_INTERVAL must be positive")
+	}
+	if cfg.SeriesLookahead <= 0 || cfg.SeriesJobInterval <= 0 {
+		log.Fatal("SERIES_LOOKAHEAD and SERIES_JOB_INTERVAL must be positive")
+	}
+	// 없으면 서명 개인 키와 TOTP 시크릿이 평문으로 저장됨
+	if len(cfg.DataEncryptionKey) == 0 {
+		if !cfg.IsDevelopment() {
+			log.Fatal("DATA_ENCRYPTION_KEY is required unless APP_ENV=development")
+		}
+		log.Println("DATA_ENCRYPTION_KEY is not set: signing keys and TOTP secrets are stored unencrypted")
 	}
 }
 
+func (c *Config) IsDevelopment() bool {
+	return c.AppEnv == "development"
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
 	}
 	return fallback
 }
+
+// 쉼표로 구분된 목록
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid integer for %s (%q), using default %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+// RFC 3339 형식. 없으면 zero time
+func getEnvTime(key string) time.Time {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Fatalf("Invalid time for %s (%q): %v", key, value, err)
+	}
+	return t
+}
+
+// base64로 인코딩된 32바이트 AES 키
+func getEnvKey(key string) []byte {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(b) != 32 {
+		log.Fatalf("%s must be a base64-encoded 32-byte key", key)
+	}
+	return b
+}
+
+// "15m", "720h" 같은 Go duration 형식
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s (%q), using default %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
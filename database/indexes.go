@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/seojoonrp/bbiyong-backend/config"
+	"github.com/seojoonrp/bbiyong-backend/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -19,6 +20,17 @@ func EnsureIndexes(client *mongo.Client) {
 	initChatIndexes(db.Collection("chats"))
 	initFriendshipIndexes(db.Collection("friendships"))
 	initSaveIndexes(db.Collection("saves"))
+	initRefreshTokenIndexes(db.Collection("refresh_tokens"))
+	initRevocationIndexes(db.Collection("revocations"))
+	initSessionIndexes(db.Collection("sessions"))
+	initSigningKeyIndexes(db.Collection("signing_keys"))
+	initLoginAttemptIndexes(db.Collection("login_attempts"))
+	initAuditLogIndexes(db.Collection("audit_logs"))
+	initVerificationTokenIndexes(db.Collection("verification_tokens"))
+	initNotificationIndexes(db.Collection("notifications"))
+	initJoinRequestIndexes(db.Collection("join_requests"))
+	initWaitlistIndexes(db.Collection("waitlists"))
+	initMeetingSeriesIndexes(db.Collection("meeting_series"))
 }
 
 func initUserIndexes(coll *mongo.Collection) {
@@ -28,6 +40,30 @@ func initUserIndexes(coll *mongo.Collection) {
 		Options: options.Index().SetUnique(true).SetName("idx_unique_username"),
 	}
 	createIndex(coll, indexModel)
+
+	// 탈퇴 유예 기간이 끝난 계정 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "deletion_scheduled_at", Value: 1}},
+		Options: options.Index().SetSparse(true).SetName("idx_deletion_scheduled_at"),
+	})
+
+	// 로컬 계정 이메일. 비밀번호 재설정 대상을 찾을 때 쓰므로 중복 불가
+	createIndex(coll, mongo.IndexModel{
+		Keys: bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"email": bson.M{"$type": "string"}}).
+			SetName("idx_unique_email"),
+	})
+
+	// 연결된 로그인 수단으로 조회. 한 소셜 계정은 한 유저에만 연결됨
+	createIndex(coll, mongo.IndexModel{
+		Keys: bson.D{{Key: "identities.key", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"identities.key": bson.M{"$exists": true}}).
+			SetName("idx_unique_identity_key"),
+	})
 }
 
 func initMeetingIndexes(coll *mongo.Collection) {
@@ -37,6 +73,27 @@ func initMeetingIndexes(coll *mongo.Collection) {
 		Options: options.Index().SetName("idx_geo_location"),
 	}
 	createIndex(coll, indexModel)
+
+	// 유저가 참여 중인 모임 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "participant_ids", Value: 1}},
+		Options: options.Index().SetName("idx_participant_ids"),
+	})
+
+	// 시작/종료 시각이 된 모임 조회 (상태 전환 스케줄러)
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "meeting_time", Value: 1}},
+		Options: options.Index().SetName("idx_status_meeting_time"),
+	})
+
+	// 반복 모임은 회차마다 하나. 회차 생성 작업이 겹쳐 돌아도 중복이 생기지 않음
+	createIndex(coll, mongo.IndexModel{
+		Keys: bson.D{{Key: "series_id", Value: 1}, {Key: "occurrence", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"series_id": bson.M{"$exists": true}}).
+			SetName("idx_unique_series_occurrence"),
+	})
 }
 
 func initChatIndexes(coll *mongo.Collection) {
@@ -79,6 +136,102 @@ func initSaveIndexes(coll *mongo.Collection) {
 		},
 		Options: options.Index().SetUnique(true).SetName("idx_unique_user_meeting_save"),
 	})
+
+	// 모임을 저장한 유저 조회 (취소 알림)
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "meeting_id", Value: 1}},
+		Options: options.Index().SetName("idx_meeting_id"),
+	})
+}
+
+func initRefreshTokenIndexes(coll *mongo.Collection) {
+	// 토큰 해시로 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "token_hash", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("idx_unique_token_hash"),
+	})
+	// 세션 폐기 시 해당 세션 토큰 일괄 폐기
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "session_id", Value: 1}},
+		Options: options.Index().SetName("idx_session_id"),
+	})
+	// 재사용 감지 시 패밀리 전체 폐기
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "family_id", Value: 1}},
+		Options: options.Index().SetName("idx_family_id"),
+	})
+	// 만료된 토큰 자동 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("idx_ttl_expires_at"),
+	})
+}
+
+func initRevocationIndexes(coll *mongo.Collection) {
+	// 폐기 대상 토큰이 만료되면 폐기 기록도 자동 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("idx_ttl_expires_at"),
+	})
+}
+
+func initSessionIndexes(coll *mongo.Collection) {
+	// 내 기기 목록 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "last_seen_at", Value: -1},
+		},
+		Options: options.Index().SetName("idx_user_id_last_seen_at"),
+	})
+
+	// 리프레시 토큰까지 만료된 기기는 자동 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("idx_ttl_expires_at"),
+	})
+}
+
+func initLoginAttemptIndexes(coll *mongo.Collection) {
+	// 실패 기록 보관 기간과 잠금이 모두 끝나면 자동 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("idx_ttl_expires_at"),
+	})
+}
+
+func initAuditLogIndexes(coll *mongo.Collection) {
+	// 특정 유저 또는 IP의 기록을 최신순으로 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "subject", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("idx_subject_created_at"),
+	})
+
+	// 전체 기록 최신순 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("idx_created_at"),
+	})
+}
+
+func initVerificationTokenIndexes(coll *mongo.Collection) {
+	// 재설정 토큰 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "token_hash", Value: 1}},
+		Options: options.Index().SetName("idx_token_hash"),
+	})
+
+	// 유저별 최근 발급 건 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "purpose", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("idx_user_id_purpose_created_at"),
+	})
+
+	// 만료된 토큰 자동 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("idx_ttl_expires_at"),
+	})
 }
 
 func createIndex(coll *mongo.Collection, model mongo.IndexModel) {
@@ -92,3 +245,92 @@ func createIndex(coll *mongo.Collection, model mongo.IndexModel) {
 	}
 	log.Printf("Successfully applied index %s on collection %s", name, coll.Name())
 }
+
+func initSigningKeyIndexes(coll *mongo.Collection) {
+	// 같은 차례의 키는 하나만. 여러 인스턴스가 동시에 교체해도 중복 생성되지 않음
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "activates_at", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("idx_unique_activates_at"),
+	})
+
+	// 검증 기간까지 지난 키는 자동 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("idx_ttl_expires_at"),
+	})
+}
+
+func initNotificationIndexes(coll *mongo.Collection) {
+	// 유저별 알림함 최신순 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("idx_user_id_created_at"),
+	})
+
+	// 90일 지난 알림은 자동 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(90 * 24 * 60 * 60).SetName("idx_ttl_created_at"),
+	})
+}
+
+func initJoinRequestIndexes(coll *mongo.Collection) {
+	// 대기 중인 신청은 모임-유저 쌍마다 하나
+	createIndex(coll, mongo.IndexModel{
+		Keys: bson.D{{Key: "meeting_id", Value: 1}, {Key: "user_id", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": models.JoinRequestPending}).
+			SetName("idx_unique_pending_meeting_user"),
+	})
+
+	// 모임별 대기 목록 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "meeting_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("idx_meeting_id_status_created_at"),
+	})
+
+	// 탈퇴 시 유저의 신청 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().SetName("idx_user_id"),
+	})
+}
+
+func initWaitlistIndexes(coll *mongo.Collection) {
+	// 한 모임에 한 번만 줄 설 수 있음
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "meeting_id", Value: 1}, {Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("idx_unique_meeting_user"),
+	})
+
+	// 모임별 대기 순서 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "meeting_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("idx_meeting_id_created_at"),
+	})
+
+	// 탈퇴 시 유저의 대기 삭제
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().SetName("idx_user_id"),
+	})
+}
+
+func initMeetingSeriesIndexes(coll *mongo.Collection) {
+	// 다음 회차를 만들 시리즈 조회
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "next_occurrence", Value: 1}},
+		Options: options.Index().SetName("idx_status_next_occurrence"),
+	})
+
+	// 탈퇴 시 방장 시리즈 종료, 멤버 정리
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "host_id", Value: 1}},
+		Options: options.Index().SetName("idx_host_id"),
+	})
+	createIndex(coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "member_ids", Value: 1}},
+		Options: options.Index().SetName("idx_member_ids"),
+	})
+}
@@ -0,0 +1,161 @@
+// jwks/cache.go
+
+package jwks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/time/rate"
+)
+
+// 발급자(issuer) 하나의 공개키 묶음을 들고 있는 캐시.
+// 서버 시작 시 한 번 만들어서 계속 재사용하고, 백그라운드에서 주기적으로 갱신함.
+// 모르는 kid가 들어오면 즉시 다시 받아오되 rate limit을 걸어서 외부 서버를 두들기지 않음
+type Cache struct {
+	issuer          string
+	url             string
+	refreshInterval time.Duration
+	kf              keyfunc.Keyfunc
+
+	mu            sync.RWMutex
+	lastRefreshAt time.Time
+	lastError     error
+	lastErrorAt   time.Time
+}
+
+type Options struct {
+	Issuer           string
+	URL              string
+	RefreshInterval  time.Duration // 정기 갱신 주기
+	UnknownKIDWindow time.Duration // 모르는 kid로 인한 재요청 최소 간격
+	HTTPTimeout      time.Duration
+}
+
+type Health struct {
+	Issuer        string     `json:"issuer"`
+	URL           string     `json:"url"`
+	Healthy       bool       `json:"healthy"`
+	KeyCount      int        `json:"keyCount"`
+	LastRefreshAt *time.Time `json:"lastRefreshAt,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastErrorAt   *time.Time `json:"lastErrorAt,omitempty"`
+}
+
+// ctx가 끝나면 갱신 고루틴도 종료됨. 첫 요청이 실패해도 에러 없이 만들어지고 이후 갱신에서 복구함
+func New(ctx context.Context, opts Options) (*Cache, error) {
+	if opts.RefreshInterval <= 0 {
+		opts.RefreshInterval = time.Hour
+	}
+	if opts.UnknownKIDWindow <= 0 {
+		opts.UnknownKIDWindow = 5 * time.Minute
+	}
+	if opts.HTTPTimeout <= 0 {
+		opts.HTTPTimeout = 10 * time.Second
+	}
+
+	c := &Cache{
+		issuer:          opts.Issuer,
+		url:             opts.URL,
+		refreshInterval: opts.RefreshInterval,
+	}
+
+	kf, err := keyfunc.NewDefaultOverrideCtx(ctx, []string{opts.URL}, keyfunc.Override{
+		Client:            &http.Client{Transport: &recordingTransport{cache: c, next: http.DefaultTransport}},
+		HTTPTimeout:       opts.HTTPTimeout,
+		RefreshInterval:   opts.RefreshInterval,
+		RefreshUnknownKID: rate.NewLimiter(rate.Every(opts.UnknownKIDWindow), 1),
+		RefreshErrorHandlerFunc: func(u string) func(ctx context.Context, err error) {
+			return func(ctx context.Context, err error) {
+				log.Printf("Failed to refresh JWKS for %s (%s): %v", opts.Issuer, u, err)
+				c.recordError(err)
+			}
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS cache for %s: %w", opts.Issuer, err)
+	}
+	c.kf = kf
+
+	return c, nil
+}
+
+func (c *Cache) Issuer() string {
+	return c.issuer
+}
+
+// jwt.Parse에 그대로 넘기는 용도
+func (c *Cache) Keyfunc(token *jwt.Token) (any, error) {
+	return c.kf.Keyfunc(token)
+}
+
+func (c *Cache) Health(ctx context.Context) Health {
+	keys, err := c.kf.Storage().KeyReadAll(ctx)
+	if err != nil {
+		keys = nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	h := Health{
+		Issuer:   c.issuer,
+		URL:      c.url,
+		KeyCount: len(keys),
+	}
+	if !c.lastRefreshAt.IsZero() {
+		t := c.lastRefreshAt
+		h.LastRefreshAt = &t
+	}
+	if c.lastError != nil {
+		t := c.lastErrorAt
+		h.LastError = c.lastError.Error()
+		h.LastErrorAt = &t
+	}
+
+	// 키가 있고, 마지막 시도가 실패가 아니며, 갱신이 너무 오래 밀리지 않았으면 정상
+	recentlyRefreshed := time.Since(c.lastRefreshAt) < 2*c.refreshInterval
+	lastAttemptOK := c.lastError == nil || c.lastRefreshAt.After(c.lastErrorAt)
+	h.Healthy = h.KeyCount > 0 && recentlyRefreshed && lastAttemptOK
+
+	return h
+}
+
+func (c *Cache) recordRefresh() {
+	c.mu.Lock()
+	c.lastRefreshAt = time.Now()
+	c.mu.Unlock()
+}
+
+func (c *Cache) recordError(err error) {
+	c.mu.Lock()
+	c.lastError = err
+	c.lastErrorAt = time.Now()
+	c.mu.Unlock()
+}
+
+// 키 묶음을 성공적으로 받아온 시각을 기록하기 위한 RoundTripper
+type recordingTransport struct {
+	cache *Cache
+	next  http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.cache.recordError(err)
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		t.cache.recordRefresh()
+	} else {
+		t.cache.recordError(fmt.Errorf("unexpected status: %d", resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,164 @@
+// mail/mailer.go
+
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// 메일 발송. 개발/테스트에서는 Log나 File 구현을 써서 실제로 보내지 않음
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("[MAIL] to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// 메일 한 통을 파일 하나로 저장. 테스트에서 디렉터리를 읽어 코드/토큰을 꺼낼 수 있음
+type FileMailer struct {
+	Dir string
+}
+
+func NewFileMailer(dir string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileMailer{Dir: dir}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), sanitizeFileName(msg.To))
+	return os.WriteFile(filepath.Join(m.Dir, name), []byte(format("", msg)), 0o644)
+}
+
+// ctx에 기한이 없으면 이 시간 안에 한 통을 다 보내야 함
+const smtpTimeout = 30 * time.Second
+
+type SMTPMailer struct {
+	Addr string
+	Host string
+	Auth smtp.Auth
+	From string
+}
+
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{
+		Addr: fmt.Sprintf("%s:%d", host, port),
+		Host: host,
+		Auth: auth,
+		From: from,
+	}
+}
+
+// smtp.SendMail은 ctx를 받지 않아서 서버가 응답하지 않으면 요청이 끝없이 묶임.
+// 같은 순서로 보내되 연결은 ctx로 열고, 기한을 걸고, ctx가 취소되면 바로 닫음
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	// SendMail과 같이 주소에 줄바꿈이 섞여 명령이 끼어드는 것을 막음
+	if strings.ContainsAny(m.From, "\r\n") || strings.ContainsAny(msg.To, "\r\n") {
+		return fmt.Errorf("mail: address contains CR or LF")
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: m.Host}); err != nil {
+			return err
+		}
+	}
+	if m.Auth != nil {
+		if ok, _ := c.Extension("AUTH"); ok {
+			if err := c.Auth(m.Auth); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := c.Mail(m.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(format(m.From, msg))); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func format(from string, msg Message) string {
+	var b strings.Builder
+	if from != "" {
+		fmt.Fprintf(&b, "From: %s\r\n", from)
+	}
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+	return b.String()
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, s)
+}
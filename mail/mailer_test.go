@@ -0,0 +1,47 @@
+// mail/mailer_test.go
+
+package mail
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// 연결만 받고 인사말을 보내지 않는 서버
+func TestSMTPMailerHonorsContext(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	m := &SMTPMailer{Addr: ln.Addr().String(), Host: "127.0.0.1", From: "noreply@example.com"}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := m.Send(ctx, Message{To: "user@example.com", Subject: "test", Body: "body"}); err == nil {
+		t.Fatal("expected error from silent server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Send took %v after context deadline", elapsed)
+	}
+}
+
+func TestSMTPMailerRejectsHeaderInjection(t *testing.T) {
+	m := &SMTPMailer{Addr: "127.0.0.1:0", Host: "127.0.0.1", From: "noreply@example.com"}
+	if err := m.Send(context.Background(), Message{To: "user@example.com\r\nBcc: x@example.com"}); err == nil {
+		t.Fatal("expected error for CRLF in recipient")
+	}
+}
@@ -5,11 +5,14 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/seojoonrp/bbiyong-backend/api/events"
 	"github.com/seojoonrp/bbiyong-backend/api/handlers"
+	"github.com/seojoonrp/bbiyong-backend/api/jobs"
 	"github.com/seojoonrp/bbiyong-backend/api/middleware"
 	"github.com/seojoonrp/bbiyong-backend/api/repositories"
 	"github.com/seojoonrp/bbiyong-backend/api/routes"
@@ -17,6 +20,8 @@ import (
 	"github.com/seojoonrp/bbiyong-backend/api/ws"
 	"github.com/seojoonrp/bbiyong-backend/config"
 	"github.com/seojoonrp/bbiyong-backend/database"
+	"github.com/seojoonrp/bbiyong-backend/jwks"
+	"github.com/seojoonrp/bbiyong-backend/mail"
 	"github.com/seojoonrp/bbiyong-backend/models"
 )
 
@@ -41,26 +46,76 @@ func main() {
 	chatRepo := repositories.NewChatRepository(db)
 	friendRepo := repositories.NewFriendRepository(db)
 	saveRepo := repositories.NewSaveRepository(db)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
+	revocationRepo := repositories.NewRevocationRepository(db)
+	sessionRepo := repositories.NewSessionRepository(db)
+	signingKeyRepo := repositories.NewSigningKeyRepository(db)
+	auditLogRepo := repositories.NewAuditLogRepository(db)
+	verificationRepo := repositories.NewVerificationTokenRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	jobLeaseRepo := repositories.NewJobLeaseRepository(db)
+	joinRequestRepo := repositories.NewJoinRequestRepository(db)
+	waitlistRepo := repositories.NewWaitlistRepository(db)
+	seriesRepo := repositories.NewMeetingSeriesRepository(db)
 
-	authService := services.NewAuthService(userRepo)
-	userService := services.NewUserService(userRepo)
-	meetingService := services.NewMeetingService(meetingRepo, meetingEventChan)
+	var loginAttemptRepo repositories.LoginAttemptRepository
+	if config.AppConfig.LoginAttemptStore == "memory" {
+		loginAttemptRepo = repositories.NewMemoryLoginAttemptRepository()
+	} else {
+		loginAttemptRepo = repositories.NewLoginAttemptRepository(db)
+	}
+
+	revocationService := services.NewRevocationService(revocationRepo, userRepo)
+	go revocationService.Run(config.AppConfig.RevocationSyncInterval)
+
+	tokenService := services.NewTokenService(signingKeyRepo)
+	if err := tokenService.Rotate(context.Background()); err != nil {
+		log.Fatal("Failed to load signing keys:", err)
+	}
+	go tokenService.Run(config.AppConfig.JWTKeyCheckInterval)
+
+	loginThrottleService := services.NewLoginThrottleService(loginAttemptRepo, auditLogRepo)
+	sessionService := services.NewSessionService(sessionRepo, refreshTokenRepo, revocationService)
+	keyCaches, identityProviders := newIdentityProviders()
+	adminService := services.NewAdminService(userRepo, auditLogRepo, sessionService, revocationService)
+	adminService.BootstrapAdmins(context.Background(), config.AppConfig.AdminBootstrapUsernames)
+	verificationService := services.NewVerificationService(userRepo, verificationRepo, sessionService, loginThrottleService, newMailer())
+	twoFactorService := services.NewTwoFactorService(userRepo, loginThrottleService)
+	authService := services.NewAuthService(userRepo, refreshTokenRepo, tokenService, loginThrottleService, verificationService, twoFactorService, sessionService, revocationService, identityProviders)
+	userService := services.NewUserService(userRepo, meetingRepo, friendRepo, chatHub)
+	notificationService := services.NewNotificationService(notificationRepo, saveRepo)
+	joinRequestService := services.NewJoinRequestService(joinRequestRepo, meetingRepo, userRepo, meetingEventChan)
+	waitlistService := services.NewWaitlistService(waitlistRepo, meetingRepo, userRepo, notificationService, meetingEventChan)
+	meetingService := services.NewMeetingService(meetingRepo, userRepo, seriesRepo, saveRepo, joinRequestRepo, joinRequestService, waitlistService, notificationService, chatHub, meetingEventChan)
+	seriesService := services.NewMeetingSeriesService(seriesRepo, meetingRepo, userRepo, meetingEventChan)
 	chatService := services.NewChatService(chatRepo, userRepo, meetingRepo)
 	friendService := services.NewFriendService(friendRepo)
 	saveService := services.NewSaveService(saveRepo, meetingRepo)
+	accountService := services.NewAccountService(userRepo, meetingRepo, friendRepo, saveRepo, chatRepo, sessionRepo, refreshTokenRepo, verificationRepo, notificationRepo, joinRequestRepo, seriesRepo, sessionService, meetingService, waitlistService, chatHub, meetingEventChan)
 
-	authHandler := handlers.NewAuthHandler(authService)
-	meetingHandler := handlers.NewMeetingHandler(meetingService)
+	authHandler := handlers.NewAuthHandler(authService, verificationService, twoFactorService)
+	meetingHandler := handlers.NewMeetingHandler(meetingService, joinRequestService, waitlistService)
 	chatHandler := handlers.NewChatHandler(chatHub, chatService, userService, meetingService)
 	friendHandler := handlers.NewFriendHandler(friendService)
 	saveHandler := handlers.NewSaveHandler(saveService)
+	userHandler := handlers.NewUserHandler(userService, sessionService, accountService)
+	healthHandler := handlers.NewHealthHandler(keyCaches)
+	jwksHandler := handlers.NewJWKSHandler(tokenService)
+	adminHandler := handlers.NewAdminHandler(adminService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	seriesHandler := handlers.NewMeetingSeriesHandler(seriesService)
 
 	go events.StartMeetingWorker(meetingEventChan, chatService, chatHub)
+	go jobs.StartAccountPurgeJob(accountService, jobLeaseRepo, config.AppConfig.AccountPurgeInterval)
+	go jobs.StartMeetingLifecycleJob(meetingService, jobLeaseRepo, config.AppConfig.MeetingLifecycleInterval)
+	go jobs.StartMeetingSeriesJob(seriesService, jobLeaseRepo, config.AppConfig.SeriesJobInterval)
 
 	router := gin.Default()
 	router.Use(cors.Default())
 	router.Use(middleware.ErrorHandler())
-	router.SetTrustedProxies(nil)
+	if err := router.SetTrustedProxies(config.AppConfig.TrustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES: ", err)
+	}
 
 	routes.SetupRoutes(
 		router,
@@ -70,9 +125,57 @@ func main() {
 		chatHandler,
 		friendHandler,
 		saveHandler,
+		userHandler,
+		healthHandler,
+		adminHandler,
+		jwksHandler,
+		notificationHandler,
+		seriesHandler,
+		tokenService,
+		revocationService,
+		userService,
 	)
 
 	port := config.AppConfig.Port
 	log.Printf("Starting server on port %s.", port)
 	router.Run(":" + port)
 }
+
+// 외부 공개키 캐시는 여기서 한 번만 만들고 서버가 끝날 때까지 재사용
+func newIdentityProviders() ([]*jwks.Cache, []services.IdentityProvider) {
+	cfg := config.AppConfig
+
+	appleKeys, err := jwks.New(context.Background(), jwks.Options{
+		Issuer:           cfg.AppleIssuer,
+		URL:              cfg.AppleJWKSURL,
+		RefreshInterval:  cfg.JWKSRefreshInterval,
+		UnknownKIDWindow: cfg.JWKSUnknownKIDWindow,
+	})
+	if err != nil {
+		log.Fatal("Failed to create Apple JWKS cache:", err)
+	}
+
+	return []*jwks.Cache{appleKeys}, []services.IdentityProvider{
+		services.NewGoogleIdentityProvider(cfg.GoogleWebClientID),
+		services.NewKakaoIdentityProvider(cfg.KakaoUserInfoURL, &http.Client{Timeout: 5 * time.Second}),
+		services.NewAppleIdentityProvider(appleKeys, cfg.AppleBundleID),
+	}
+}
+
+func newMailer() mail.Mailer {
+	cfg := config.AppConfig
+
+	// log와 file은 validateConfig에서 개발 환경일 때만 통과함
+	switch cfg.MailMode {
+	case "log":
+		return mail.NewLogMailer()
+	case "file":
+		mailer, err := mail.NewFileMailer(cfg.MailFileDir)
+		if err != nil {
+			log.Fatal("Failed to create mail directory:", err)
+		}
+		return mailer
+	default:
+		return mail.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	}
+}
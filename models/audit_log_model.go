@@ -0,0 +1,28 @@
+// models/audit_log_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AuditActionAccountLocked   = "ACCOUNT_LOCKED"
+	AuditActionIPLocked        = "IP_LOCKED"
+	AuditActionRoleChanged     = "ROLE_CHANGED"
+	AuditActionSessionsRevoked = "SESSIONS_REVOKED"
+)
+
+// 보안 관련 이벤트 기록. 운영자가 조회하는 용도라 삭제하지 않음
+type AuditLog struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Action    string              `bson:"action" json:"action"`
+	UserID    *primitive.ObjectID `bson:"user_id,omitempty" json:"userId,omitempty"`
+	ActorID   *primitive.ObjectID `bson:"actor_id,omitempty" json:"actorId,omitempty"` // 운영자가 한 조치일 때
+	Subject   string              `bson:"subject" json:"subject"`
+	IP        string              `bson:"ip,omitempty" json:"ip,omitempty"`
+	Detail    string              `bson:"detail,omitempty" json:"detail,omitempty"`
+	CreatedAt time.Time           `bson:"created_at" json:"createdAt"`
+}
@@ -9,9 +9,15 @@ import (
 )
 
 const (
-	ChatTypeTalk  = "TALK"
-	ChatTypeJoin  = "JOIN"
-	ChatTypeLeave = "LEAVE"
+	ChatTypeTalk   = "TALK"
+	ChatTypeJoin   = "JOIN"
+	ChatTypeLeave  = "LEAVE"
+	ChatTypeUpdate = "UPDATE" // 방장이 모임 정보를 바꿈
+	ChatTypeCancel = "CANCEL" // 이후로 채팅방은 읽기 전용
+	ChatTypeStart  = "START"
+	ChatTypeFinish = "FINISH"
+	ChatTypeRole   = "ROLE" // 방장, 공동 방장 변경
+	ChatTypeKick   = "KICK"
 )
 
 type ChatMessage struct {
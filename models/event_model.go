@@ -3,12 +3,22 @@
 package models
 
 const (
-	EventJoinMeeting  = "JOIN"
-	EventLeaveMeeting = "LEAVE"
+	EventJoinMeeting   = "JOIN"
+	EventLeaveMeeting  = "LEAVE"
+	EventUpdateMeeting = "UPDATE"
+	EventCancelMeeting = "CANCEL"
+	EventStartMeeting  = "START"
+	EventFinishMeeting = "FINISH"
+	EventTransferHost  = "HOST_TRANSFER"
+	EventAddCoHost     = "CO_HOST_ADD"
+	EventRemoveCoHost  = "CO_HOST_REMOVE"
+	EventKickMember    = "KICK"
 )
 
 type MeetingEvent struct {
 	Type      string
 	MeetingID string
-	UserID    string
+	UserID    string   // 스케줄러가 만든 이벤트는 비어 있음
+	Changes   []string // UPDATE일 때 바뀐 항목 이름
+	Reason    string   // CANCEL일 때 취소 사유
 }
@@ -0,0 +1,12 @@
+// models/job_lease_model.go
+
+package models
+
+import "time"
+
+// 여러 인스턴스 중 하나만 작업을 돌리도록 잡는 임대. ExpiresAt이 지나면 다른 인스턴스가 가져갈 수 있음
+type JobLease struct {
+	Name      string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
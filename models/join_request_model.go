@@ -0,0 +1,43 @@
+// models/join_request_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	JoinModeInstant  = "INSTANT"  // 누르면 바로 참여
+	JoinModeApproval = "APPROVAL" // 방장이나 공동 방장이 승인해야 참여
+)
+
+const (
+	JoinRequestPending   = "PENDING"
+	JoinRequestApproved  = "APPROVED"
+	JoinRequestRejected  = "REJECTED"
+	JoinRequestCancelled = "CANCELLED"
+)
+
+type JoinRequest struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	MeetingID primitive.ObjectID  `bson:"meeting_id" json:"meetingID"`
+	UserID    primitive.ObjectID  `bson:"user_id" json:"userID"`
+	Message   string              `bson:"message,omitempty" json:"message,omitempty"` // 신청하면서 방장에게 남기는 말
+	Status    string              `bson:"status" json:"status"`
+	DecidedBy *primitive.ObjectID `bson:"decided_by,omitempty" json:"-"`
+	DecidedAt *time.Time          `bson:"decided_at,omitempty" json:"decidedAt,omitempty"`
+	CreatedAt time.Time           `bson:"created_at" json:"createdAt"`
+}
+
+// 방장이 보는 대기 목록. 신청자 정보를 붙여서 내려줌
+type JoinRequestView struct {
+	JoinRequest `bson:",inline"`
+	Applicant   *UserSummary `bson:"applicant" json:"applicant"`
+}
+
+// 바디는 선택
+type JoinMeetingRequest struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,14 @@
+// models/login_attempt_model.go
+
+package models
+
+import "time"
+
+// 로그인 실패 카운터. Key는 "user:<username>" 또는 "ip:<address>"
+type LoginAttempt struct {
+	Key           string    `bson:"_id"`
+	Failures      int       `bson:"failures"`
+	LastFailureAt time.Time `bson:"last_failure_at"`
+	LockedUntil   time.Time `bson:"locked_until,omitempty"`
+	ExpiresAt     time.Time `bson:"expires_at"`
+}
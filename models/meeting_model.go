@@ -13,8 +13,12 @@ const (
 	MeetingStatusFull       = "FULL"
 	MeetingStatusOngoing    = "ONGOING"
 	MeetingStatusFinished   = "FINISHED"
+	MeetingStatusCancelled  = "CANCELLED"
 )
 
+// 방장이 탈퇴해서 시스템이 취소할 때 채팅방과 알림에 남는 사유
+const CancelReasonHostDeleted = "방장 탈퇴"
+
 type Meeting struct {
 	ID              primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
 	Title           string               `bson:"title" json:"title"`
@@ -27,11 +31,168 @@ type Meeting struct {
 	DayOfWeek       int                  `bson:"day_of_week" json:"dayOfWeek"`
 	AgeRange        [2]int               `bson:"age_range" json:"ageRange"`
 	HostID          primitive.ObjectID   `bson:"host_id" json:"hostID"`
+	CoHostIDs       []primitive.ObjectID `bson:"co_host_ids,omitempty" json:"coHostIDs"` // 방장 권한을 나눠 가진 참여자
+	BannedIDs       []primitive.ObjectID `bson:"banned_ids,omitempty" json:"-"`          // 강퇴되면서 재참여가 막힌 유저
 	Status          string               `bson:"status" json:"status"`
 	ParticipantIDs  []primitive.ObjectID `bson:"participant_ids" json:"participantIDs"`
 	MaxParticipants int                  `bson:"max_participants" json:"maxParticipants"`
+	JoinMode        string               `bson:"join_mode,omitempty" json:"joinMode"`
+	WaitlistCount   int                  `bson:"waitlist_count,omitempty" json:"waitlistCount"` // 0보다 크면 빈자리는 대기열 몫
 	SaveCount       int                  `bson:"save_count" json:"saveCount"`
 	CreatedAt       time.Time            `bson:"created_at" json:"createdAt"`
+
+	// 없으면 성별 제한 없음. 성별 정원을 원자적으로 확인하려고 참여할 때 성별을 같이 기록함
+	GenderQuota        *GenderQuota        `bson:"gender_quota,omitempty" json:"genderQuota,omitempty"`
+	ParticipantGenders []ParticipantGender `bson:"participant_genders,omitempty" json:"-"`
+	WaitlistGenders    map[string]int      `bson:"waitlist_genders,omitempty" json:"-"` // 성별별 대기자 수. 성별을 모르는 대기자는 waitlist_count에만 셈
+
+	// 반복 모임의 회차면 어느 시리즈의 몇 번째(1부터)인지
+	SeriesID   *primitive.ObjectID `bson:"series_id,omitempty" json:"seriesID,omitempty"`
+	Occurrence int                 `bson:"occurrence,omitempty" json:"occurrence,omitempty"`
+}
+
+// 성별별 최대 인원. 0이면 그 성별은 참여할 수 없음
+type GenderQuota struct {
+	Male   int `bson:"male" json:"male"`
+	Female int `bson:"female" json:"female"`
+}
+
+// 알 수 없는 성별이면 false
+func (q *GenderQuota) Limit(gender string) (int, bool) {
+	switch gender {
+	case GenderMale:
+		return q.Male, true
+	case GenderFemale:
+		return q.Female, true
+	}
+	return 0, false
+}
+
+type ParticipantGender struct {
+	UserID primitive.ObjectID `bson:"user_id"`
+	Gender string             `bson:"gender"`
+}
+
+// AgeRange는 [최소, 최대]. 0이면 그쪽은 제한 없음
+func (m *Meeting) AllowsAge(age int) bool {
+	if m.AgeRange[0] > 0 && age < m.AgeRange[0] {
+		return false
+	}
+	if m.AgeRange[1] > 0 && age > m.AgeRange[1] {
+		return false
+	}
+	return true
+}
+
+func (m *Meeting) AllowsGender(gender string) bool {
+	if m.GenderQuota == nil {
+		return true
+	}
+	limit, ok := m.GenderQuota.Limit(gender)
+	return ok && limit > 0
+}
+
+// 성별 정원이 있고 그 성별 자리가 다 찼는지
+func (m *Meeting) IsGenderFull(gender string) bool {
+	if m.GenderQuota == nil {
+		return false
+	}
+	limit, _ := m.GenderQuota.Limit(gender)
+
+	count := 0
+	for _, p := range m.ParticipantGenders {
+		if p.Gender == gender {
+			count++
+		}
+	}
+	return count >= limit
+}
+
+// 이 성별로 바로 참여하려 할 때 빈자리를 먼저 가져갈 대기자가 있는지.
+// 성별 정원이 있으면 자리가 남은 성별의 대기자만 빈자리를 가져갈 수 있음
+func (m *Meeting) HasWaitersAhead(gender string) bool {
+	if m.GenderQuota == nil {
+		return m.WaitlistCount > 0
+	}
+	if m.WaitlistGenders[gender] > 0 {
+		return true
+	}
+	for _, other := range []string{GenderMale, GenderFemale} {
+		if other != gender && m.WaitlistGenders[other] > 0 && !m.IsGenderFull(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// 모집 중이거나 정원만 찬 상태. 진행 중, 종료, 취소된 모임에는 참여나 대기를 받지 않음
+func (m *Meeting) IsActive() bool {
+	return m.Status == MeetingStatusRecruiting || m.Status == MeetingStatusFull
+}
+
+// 참여 방식이 생기기 전에 만든 모임은 바로 참여
+func (m *Meeting) EffectiveJoinMode() string {
+	if m.JoinMode == "" {
+		return JoinModeInstant
+	}
+	return m.JoinMode
+}
+
+func IsValidJoinMode(mode string) bool {
+	return mode == JoinModeInstant || mode == JoinModeApproval
+}
+
+func (m *Meeting) IsBanned(userID primitive.ObjectID) bool {
+	for _, id := range m.BannedIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Meeting) IsCoHost(userID primitive.ObjectID) bool {
+	for _, id := range m.CoHostIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// 방장이나 공동 방장. 모임 수정, 취소 등을 할 수 있음
+func (m *Meeting) CanManage(userID primitive.ObjectID) bool {
+	return m.HostID == userID || m.IsCoHost(userID)
+}
+
+// 참여할 수 없는 이유. 참여 가능하면 비어 있음
+const (
+	JoinBlockedClosed     = "MEETING_CLOSED" // 모집 중이 아님 (진행 중, 종료, 취소)
+	JoinBlockedFull       = "MEETING_FULL"
+	JoinBlockedBanned     = "BANNED"
+	JoinBlockedAge        = "AGE_NOT_ALLOWED"
+	JoinBlockedGender     = "GENDER_NOT_ALLOWED"
+	JoinBlockedGenderFull = "GENDER_QUOTA_FULL" // 전체 자리는 남았지만 내 성별 자리가 없음
+)
+
+// 모임 상세. 참여자는 요약 정보로 풀어서 내려줌
+type MeetingDetail struct {
+	Meeting      `bson:",inline"`
+	Host         *UserSummary  `bson:"-" json:"host"`
+	Participants []UserSummary `bson:"participants" json:"participants"`
+	Viewer       MeetingViewer `bson:"-" json:"viewer"`
+}
+
+// 조회한 사람 입장에서의 모임 상태
+type MeetingViewer struct {
+	IsHost            bool   `json:"isHost"`
+	IsCoHost          bool   `json:"isCoHost"`
+	Joined            bool   `json:"joined"`
+	Saved             bool   `json:"saved"`
+	CanJoin           bool   `json:"canJoin"`
+	JoinPending       bool   `json:"joinPending"` // 승인제 모임에 신청해 두고 기다리는 중
+	WaitlistPosition  int64  `json:"waitlistPosition,omitempty"`
+	JoinBlockedReason string `json:"joinBlockedReason,omitempty"`
 }
 
 type CreateMeetingRequest struct {
@@ -45,4 +206,34 @@ type CreateMeetingRequest struct {
 	DayOfWeek       int       `json:"dayOfWeek" binding:"required"`
 	AgeRange        [2]int    `json:"ageRange" binding:"required"`
 	MaxParticipants int       `json:"maxParticipants" binding:"required"`
+	JoinMode        string    `json:"joinMode"` // 비어 있으면 INSTANT
+
+	GenderQuota *GenderQuota `json:"genderQuota"`
+}
+
+type TransferHostRequest struct {
+	UserID string `json:"userID" binding:"required"`
+}
+
+type KickParticipantRequest struct {
+	Ban bool `json:"ban"` // 다시 참여하지 못하게 막음
+}
+
+type CancelMeetingRequest struct {
+	Reason string `json:"reason"`
+}
+
+// 방장이 보낸 필드만 수정
+type UpdateMeetingRequest struct {
+	Title           *string    `json:"title"`
+	Description     *string    `json:"description"`
+	Category        *string    `json:"category"`
+	ImageURL        *string    `json:"imageURL"`
+	PlaceName       *string    `json:"placeName"`
+	Location        *Location  `json:"location"`
+	MeetingTime     *time.Time `json:"meetingTime"`
+	AgeRange        *[2]int    `json:"ageRange"`
+	MaxParticipants *int       `json:"maxParticipants"`
+	JoinMode        *string    `json:"joinMode"`
+	Scope           string     `json:"scope"` // 비어 있으면 THIS
 }
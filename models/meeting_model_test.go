@@ -0,0 +1,145 @@
+// models/meeting_model_test.go
+
+package models
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func participantsWith(genders ...string) []ParticipantGender {
+	participants := make([]ParticipantGender, len(genders))
+	for i, g := range genders {
+		participants[i] = ParticipantGender{UserID: primitive.NewObjectID(), Gender: g}
+	}
+	return participants
+}
+
+func TestHasWaitersAhead(t *testing.T) {
+	quota := &GenderQuota{Male: 2, Female: 2}
+
+	tests := []struct {
+		name    string
+		meeting Meeting
+		gender  string
+		want    bool
+	}{
+		{
+			name:    "no quota, empty waitlist",
+			meeting: Meeting{},
+			gender:  GenderMale,
+			want:    false,
+		},
+		{
+			name:    "no quota, any waiter blocks",
+			meeting: Meeting{WaitlistCount: 1},
+			gender:  GenderMale,
+			want:    true,
+		},
+		{
+			name: "female waiting for full female quota does not block male",
+			meeting: Meeting{
+				GenderQuota:        quota,
+				ParticipantGenders: participantsWith(GenderFemale, GenderFemale),
+				WaitlistCount:      1,
+				WaitlistGenders:    map[string]int{GenderFemale: 1},
+			},
+			gender: GenderMale,
+			want:   false,
+		},
+		{
+			name: "same gender waiter blocks",
+			meeting: Meeting{
+				GenderQuota:        quota,
+				ParticipantGenders: participantsWith(GenderFemale, GenderFemale),
+				WaitlistCount:      1,
+				WaitlistGenders:    map[string]int{GenderFemale: 1},
+			},
+			gender: GenderFemale,
+			want:   true,
+		},
+		{
+			name: "other gender waiter with open slot blocks",
+			meeting: Meeting{
+				GenderQuota:        quota,
+				ParticipantGenders: participantsWith(GenderFemale),
+				WaitlistCount:      1,
+				WaitlistGenders:    map[string]int{GenderFemale: 1},
+			},
+			gender: GenderMale,
+			want:   true,
+		},
+		{
+			name: "drained gender counter does not block",
+			meeting: Meeting{
+				GenderQuota:     quota,
+				WaitlistGenders: map[string]int{GenderMale: 0, GenderFemale: 0},
+			},
+			gender: GenderFemale,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.meeting.HasWaitersAhead(tt.gender); got != tt.want {
+				t.Errorf("HasWaitersAhead(%s) = %v, want %v", tt.gender, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenderQuotaChecks(t *testing.T) {
+	tests := []struct {
+		name        string
+		meeting     Meeting
+		gender      string
+		wantAllowed bool
+		wantFull    bool
+	}{
+		{"no quota", Meeting{}, GenderFemale, true, false},
+		{"open slot", Meeting{GenderQuota: &GenderQuota{Male: 2, Female: 2}, ParticipantGenders: participantsWith(GenderMale)}, GenderMale, true, false},
+		{"slots taken", Meeting{GenderQuota: &GenderQuota{Male: 2, Female: 2}, ParticipantGenders: participantsWith(GenderMale, GenderMale)}, GenderMale, true, true},
+		{"other gender does not count", Meeting{GenderQuota: &GenderQuota{Male: 1, Female: 2}, ParticipantGenders: participantsWith(GenderFemale, GenderFemale)}, GenderMale, true, false},
+		{"zero slots", Meeting{GenderQuota: &GenderQuota{Male: 0, Female: 4}}, GenderMale, false, true},
+		{"unknown gender", Meeting{GenderQuota: &GenderQuota{Male: 2, Female: 2}}, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.meeting.AllowsGender(tt.gender); got != tt.wantAllowed {
+				t.Errorf("AllowsGender(%q) = %v, want %v", tt.gender, got, tt.wantAllowed)
+			}
+			if got := tt.meeting.IsGenderFull(tt.gender); got != tt.wantFull {
+				t.Errorf("IsGenderFull(%q) = %v, want %v", tt.gender, got, tt.wantFull)
+			}
+		})
+	}
+}
+
+func TestAllowsAge(t *testing.T) {
+	tests := []struct {
+		name     string
+		ageRange [2]int
+		age      int
+		want     bool
+	}{
+		{"no limit", [2]int{0, 0}, 17, true},
+		{"inside", [2]int{20, 30}, 25, true},
+		{"lower bound inclusive", [2]int{20, 30}, 20, true},
+		{"upper bound inclusive", [2]int{20, 30}, 30, true},
+		{"too young", [2]int{20, 30}, 19, false},
+		{"too old", [2]int{20, 30}, 31, false},
+		{"no upper bound", [2]int{20, 0}, 70, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Meeting{AgeRange: tt.ageRange}
+			if got := m.AllowsAge(tt.age); got != tt.want {
+				t.Errorf("AllowsAge(%d) = %v, want %v", tt.age, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// models/meeting_series_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RecurrenceWeekly   = "WEEKLY"
+	RecurrenceBiweekly = "BIWEEKLY"
+)
+
+const (
+	SeriesStatusActive = "ACTIVE"
+	SeriesStatusEnded  = "ENDED" // 마지막 회차까지 만들었거나 방장이 탈퇴함
+)
+
+// 모임 수정 범위. 반복 모임의 회차에서만 FOLLOWING을 쓸 수 있음
+const (
+	EditScopeThis      = "THIS"
+	EditScopeFollowing = "FOLLOWING" // 이 회차와 이후 회차 전부, 앞으로 만들어질 회차까지
+)
+
+// 종료일(Until)과 횟수(Count) 중 하나만 지정
+type RecurrenceRule struct {
+	Frequency string     `bson:"frequency" json:"frequency" binding:"required"`
+	Until     *time.Time `bson:"until,omitempty" json:"until,omitempty"`
+	Count     int        `bson:"count,omitempty" json:"count,omitempty"`
+}
+
+func (r RecurrenceRule) Interval() time.Duration {
+	if r.Frequency == RecurrenceBiweekly {
+		return 14 * 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// 반복 모임. 회차마다 일반 모임(채팅방 포함)을 미리 만들어 두고, 아래 필드가 그 틀이 됨
+// bson 이름을 Meeting과 맞춰 두어서 FOLLOWING 수정 때 같은 업데이트를 그대로 적용함
+type MeetingSeries struct {
+	ID              primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Title           string               `bson:"title" json:"title"`
+	Description     string               `bson:"description" json:"description"`
+	Category        string               `bson:"category" json:"category"`
+	ImageURL        string               `bson:"image_url" json:"imageURL"`
+	PlaceName       string               `bson:"place_name" json:"placeName"`
+	Location        Location             `bson:"location" json:"location"`
+	AgeRange        [2]int               `bson:"age_range" json:"ageRange"`
+	GenderQuota     *GenderQuota         `bson:"gender_quota,omitempty" json:"genderQuota,omitempty"`
+	MaxParticipants int                  `bson:"max_participants" json:"maxParticipants"`
+	JoinMode        string               `bson:"join_mode" json:"joinMode"`
+	HostID          primitive.ObjectID   `bson:"host_id" json:"hostID"`
+	MemberIDs       []primitive.ObjectID `bson:"member_ids" json:"memberIDs"`   // 매 회차에 자동으로 참여하는 고정 멤버 (방장 제외)
+	BannedIDs       []primitive.ObjectID `bson:"banned_ids,omitempty" json:"-"` // 회차에서 강퇴되면서 막힌 유저. 새 회차에도 이어짐
+	Recurrence      RecurrenceRule       `bson:"recurrence" json:"recurrence"`
+	StartTime       time.Time            `bson:"start_time" json:"startTime"`           // 첫 회차 시각. 이후 회차는 여기서 주기만큼 더함
+	Materialized    int                  `bson:"materialized" json:"materialized"`      // 지금까지 처리한 회차 수
+	NextOccurrence  time.Time            `bson:"next_occurrence" json:"nextOccurrence"` // 다음에 만들 회차 시각
+	Status          string               `bson:"status" json:"status"`
+	CreatedAt       time.Time            `bson:"created_at" json:"createdAt"`
+}
+
+// n번째(0부터) 회차 시각
+func (s *MeetingSeries) OccurrenceTime(n int) time.Time {
+	return s.StartTime.Add(time.Duration(n) * s.Recurrence.Interval())
+}
+
+// n번째(0부터) 회차가 반복 범위를 벗어났는지
+func (s *MeetingSeries) IsPastEnd(n int) bool {
+	if s.Recurrence.Count > 0 {
+		return n >= s.Recurrence.Count
+	}
+	return s.Recurrence.Until != nil && s.OccurrenceTime(n).After(*s.Recurrence.Until)
+}
+
+func (s *MeetingSeries) IsMember(userID primitive.ObjectID) bool {
+	for _, id := range s.MemberIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// 틀로 n번째(0부터) 회차 모임을 만듦. 참여자는 방장만 넣어 둠
+func (s *MeetingSeries) NewOccurrence(n int, dayOfWeek int) Meeting {
+	seriesID := s.ID
+	return Meeting{
+		Title:           s.Title,
+		Description:     s.Description,
+		Category:        s.Category,
+		ImageURL:        s.ImageURL,
+		PlaceName:       s.PlaceName,
+		Location:        s.Location,
+		MeetingTime:     s.OccurrenceTime(n),
+		DayOfWeek:       dayOfWeek,
+		AgeRange:        s.AgeRange,
+		HostID:          s.HostID,
+		BannedIDs:       s.BannedIDs,
+		Status:          MeetingStatusRecruiting,
+		ParticipantIDs:  []primitive.ObjectID{s.HostID},
+		MaxParticipants: s.MaxParticipants,
+		JoinMode:        s.JoinMode,
+		CreatedAt:       time.Now(),
+		GenderQuota:     s.GenderQuota,
+		SeriesID:        &seriesID,
+		Occurrence:      n + 1,
+	}
+}
+
+type MeetingSeriesDetail struct {
+	MeetingSeries
+	Occurrences []Meeting `json:"occurrences"` // 아직 끝나지 않은 회차
+	IsMember    bool      `json:"isMember"`
+}
+
+type CreateMeetingSeriesRequest struct {
+	CreateMeetingRequest
+	Recurrence RecurrenceRule `json:"recurrence" binding:"required"`
+}
@@ -0,0 +1,62 @@
+// models/meeting_series_model_test.go
+
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOccurrenceTime(t *testing.T) {
+	start := time.Date(2026, 3, 2, 19, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		frequency string
+		n         int
+		want      time.Time
+	}{
+		{"weekly first", RecurrenceWeekly, 0, start},
+		{"weekly third", RecurrenceWeekly, 2, start.AddDate(0, 0, 14)},
+		{"biweekly second", RecurrenceBiweekly, 1, start.AddDate(0, 0, 14)},
+		{"biweekly fourth", RecurrenceBiweekly, 3, start.AddDate(0, 0, 42)},
+		{"unknown frequency falls back to weekly", "DAILY", 1, start.AddDate(0, 0, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &MeetingSeries{StartTime: start, Recurrence: RecurrenceRule{Frequency: tt.frequency}}
+			if got := s.OccurrenceTime(tt.n); !got.Equal(tt.want) {
+				t.Errorf("OccurrenceTime(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPastEnd(t *testing.T) {
+	start := time.Date(2026, 3, 2, 19, 0, 0, 0, time.UTC)
+	until := func(d time.Time) *time.Time { return &d }
+
+	tests := []struct {
+		name string
+		rule RecurrenceRule
+		n    int
+		want bool
+	}{
+		{"count last occurrence", RecurrenceRule{Frequency: RecurrenceWeekly, Count: 3}, 2, false},
+		{"count one past", RecurrenceRule{Frequency: RecurrenceWeekly, Count: 3}, 3, true},
+		{"until on the day is inside", RecurrenceRule{Frequency: RecurrenceWeekly, Until: until(start.AddDate(0, 0, 7))}, 1, false},
+		{"until just before occurrence", RecurrenceRule{Frequency: RecurrenceWeekly, Until: until(start.AddDate(0, 0, 7).Add(-time.Minute))}, 1, true},
+		{"biweekly until skips a week", RecurrenceRule{Frequency: RecurrenceBiweekly, Until: until(start.AddDate(0, 0, 10))}, 1, true},
+		{"no end", RecurrenceRule{Frequency: RecurrenceWeekly}, 100, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &MeetingSeries{StartTime: start, Recurrence: tt.rule}
+			if got := s.IsPastEnd(tt.n); got != tt.want {
+				t.Errorf("IsPastEnd(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// models/notification_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	NotificationMeetingCancelled = "MEETING_CANCELLED"
+	NotificationWaitlistPromoted = "WAITLIST_PROMOTED"
+	NotificationWaitlistCleared  = "WAITLIST_CLEARED"
+)
+
+// 앱 내 알림함에 쌓이는 알림
+type Notification struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID  `bson:"user_id" json:"-"`
+	Type      string              `bson:"type" json:"type"`
+	MeetingID *primitive.ObjectID `bson:"meeting_id,omitempty" json:"meetingID,omitempty"`
+	Title     string              `bson:"title" json:"title"`
+	Body      string              `bson:"body" json:"body"`
+	ReadAt    *time.Time          `bson:"read_at,omitempty" json:"readAt,omitempty"`
+	CreatedAt time.Time           `bson:"created_at" json:"createdAt"`
+}
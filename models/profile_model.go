@@ -0,0 +1,57 @@
+// models/profile_model.go
+
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// 조회하는 사람과의 관계. 관계에 따라 공개 프로필에 보이는 필드가 달라짐
+const (
+	RelationshipSelf     = "SELF"
+	RelationshipFriend   = "FRIEND"
+	RelationshipStranger = "STRANGER"
+)
+
+// 아직 수락되지 않은 친구 요청의 방향
+const (
+	FriendRequestSent     = "SENT"
+	FriendRequestReceived = "RECEIVED"
+)
+
+// 다른 화면에 끼워 넣는 최소한의 유저 정보
+type UserSummary struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	Nickname   string             `bson:"nickname" json:"nickname"`
+	ProfileURI string             `bson:"profile_uri" json:"profileURI"`
+	Level      int                `bson:"level" json:"level"`
+}
+
+type ActivityCounts struct {
+	HostedCount   int64 `json:"hostedCount"`
+	AttendedCount int64 `json:"attendedCount"`
+	FriendCount   int64 `json:"friendCount"`
+}
+
+// 내 프로필. 비공개 필드까지 전부 포함
+type MyProfile struct {
+	User
+	ActivityCounts
+}
+
+// 다른 사람이 보는 프로필. 이메일, 정확한 위치, 로그인 수단은 누구에게도 보이지 않음
+type PublicProfile struct {
+	ID         primitive.ObjectID `json:"id"`
+	Nickname   string             `json:"nickname"`
+	ProfileURI string             `json:"profileURI"`
+	Level      int                `json:"level"`
+	RegionName string             `json:"regionName"`
+	ActivityCounts
+
+	// 친구에게만 공개
+	Age    *int   `json:"age,omitempty"`
+	Gender string `json:"gender,omitempty"`
+
+	Relationship      string        `json:"relationship"`
+	FriendRequest     string        `json:"friendRequest,omitempty"`
+	MutualFriendCount int           `json:"mutualFriendCount"`
+	MutualFriends     []UserSummary `json:"mutualFriends"`
+}
@@ -0,0 +1,29 @@
+// models/revocation_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RevocationKindToken   = "TOKEN"   // jti 하나만 폐기
+	RevocationKindUser    = "USER"    // 해당 유저의 토큰 버전이 MinVersion보다 낮은 토큰 전부 폐기
+	RevocationKindSession = "SESSION" // 해당 세션(sid)에 묶인 토큰 전부 폐기
+)
+
+// ExpiresAt이 지나면 폐기 대상 토큰도 어차피 만료되므로 TTL 인덱스로 삭제됨
+type Revocation struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Kind       string             `bson:"kind"`
+	Subject    string             `bson:"subject"`
+	MinVersion int                `bson:"min_version,omitempty"`
+	ExpiresAt  time.Time          `bson:"expires_at"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
@@ -0,0 +1,31 @@
+// models/session_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 로그인한 기기 하나당 세션 하나. 토큰에는 sid 클레임으로 묶임
+type Session struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"-"`
+	Provider   string             `bson:"provider" json:"provider"`
+	DeviceName string             `bson:"device_name" json:"deviceName"`
+	UserAgent  string             `bson:"user_agent" json:"userAgent"`
+	LastSeenIP string             `bson:"last_seen_ip" json:"lastSeenIP"`
+	LastSeenAt time.Time          `bson:"last_seen_at" json:"lastSeenAt"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"-"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expiresAt"` // 마지막 리프레시 토큰과 같이 만료. TTL 인덱스로 삭제
+	CreatedAt  time.Time          `bson:"created_at" json:"createdAt"`
+	IsCurrent  bool               `bson:"-" json:"isCurrent"`
+}
+
+// 로그인 요청에서 뽑아낸 기기 정보
+type ClientInfo struct {
+	DeviceName string
+	IP         string
+	UserAgent  string
+}
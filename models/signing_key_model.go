@@ -0,0 +1,21 @@
+// models/signing_key_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JWT 서명 키. ActivatesAt ~ RetiresAt 동안 서명에 쓰이고, ExpiresAt까지는 검증용으로 JWKS에 남아 있음
+type SigningKey struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	KID           string             `bson:"kid"`
+	Algorithm     string             `bson:"algorithm"`
+	PrivateKeyPEM string             `bson:"private_key_pem"` // DATA_ENCRYPTION_KEY가 있으면 암호화되어 저장
+	ActivatesAt   time.Time          `bson:"activates_at"`
+	RetiresAt     time.Time          `bson:"retires_at"`
+	ExpiresAt     time.Time          `bson:"expires_at"`
+	CreatedAt     time.Time          `bson:"created_at"`
+}
@@ -0,0 +1,44 @@
+// models/token_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 한 번 로그인해서 이어지는 리프레시 토큰들은 같은 FamilyID를 공유함
+type RefreshToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	FamilyID  primitive.ObjectID `bson:"family_id"`
+	SessionID primitive.ObjectID `bson:"session_id"`
+	TokenHash string             `bson:"token_hash"`
+	UsedAt    *time.Time         `bson:"used_at,omitempty"`
+	RevokedAt *time.Time         `bson:"revoked_at,omitempty"`
+	ExpiresAt time.Time          `bson:"expires_at"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// 검증을 통과한 액세스 토큰의 클레임
+type AccessClaims struct {
+	UserID          string
+	SessionID       string
+	Role            string
+	JTI             string
+	ProfileSet      bool
+	DeletionPending bool
+	TokenVersion    int
+	IssuedAt        time.Time
+	ExpiresAt       time.Time
+}
@@ -0,0 +1,29 @@
+// models/two_factor_model.go
+
+package models
+
+import "time"
+
+// TOTP 2단계 인증. 시크릿은 DATA_ENCRYPTION_KEY로 암호화해서 저장하고, 복구 코드는 해시만 저장
+type TwoFactor struct {
+	Enabled            bool       `bson:"enabled" json:"enabled"`
+	Secret             string     `bson:"secret,omitempty" json:"-"`
+	PendingSecret      string     `bson:"pending_secret,omitempty" json:"-"` // 등록 중, 첫 코드 확인 전
+	RecoveryCodeHashes []string   `bson:"recovery_code_hashes,omitempty" json:"-"`
+	LastUsedStep       int64      `bson:"last_used_step" json:"-"` // 같은 코드 재사용 방지
+	EnabledAt          *time.Time `bson:"enabled_at,omitempty" json:"enabledAt,omitempty"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type TwoFactorSetup struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauthURI"`
+}
@@ -15,32 +15,104 @@ const (
 	ProviderApple  = "APPLE"
 )
 
+// 권한 순서: USER < MODERATOR < ADMIN
+const (
+	RoleUser      = "USER"
+	RoleModerator = "MODERATOR"
+	RoleAdmin     = "ADMIN"
+)
+
+var roleRanks = map[string]int{
+	RoleUser:      1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+func IsValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// role이 minimum 이상의 권한인지. 알 수 없는 역할은 권한 없음으로 취급
+func RoleAtLeast(role, minimum string) bool {
+	return roleRanks[role] > 0 && roleRanks[role] >= roleRanks[minimum]
+}
+
 const (
 	GenderMale   = "MALE"
 	GenderFemale = "FEMALE"
 )
 
 type User struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Username     string             `bson:"username" json:"username"`
-	Password     string             `bson:"password,omitempty" json:"-"`
-	Nickname     string             `bson:"nickname" json:"nickname"`
-	ProfileURI   string             `bson:"profile_uri" json:"profileURI"`
-	Age          int                `bson:"age" json:"age"`
-	Gender       string             `bson:"gender" json:"gender"`
-	Level        int                `bson:"level" json:"level"`
-	Location     Location           `bson:"location" json:"location"`
-	RegionName   string             `bson:"region_name" json:"regionName"`
-	Provider     string             `bson:"provider" json:"provider"`
-	SocialID     string             `bson:"social_id,omitempty" json:"socialID,omitempty"`
-	SocialEmail  string             `bson:"social_email,omitempty" json:"socialEmail,omitempty"`
-	IsProfileSet bool               `bson:"is_profile_set" json:"isProfileSet"`
-	CreatedAt    time.Time          `bson:"created_at" json:"createdAt"`
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Username      string             `bson:"username" json:"username"`
+	Password      string             `bson:"password,omitempty" json:"-"`
+	Nickname      string             `bson:"nickname" json:"nickname"`
+	ProfileURI    string             `bson:"profile_uri" json:"profileURI"`
+	Age           int                `bson:"age" json:"age"`
+	Gender        string             `bson:"gender" json:"gender"`
+	Level         int                `bson:"level" json:"level"`
+	Location      Location           `bson:"location" json:"location"`
+	RegionName    string             `bson:"region_name" json:"regionName"`
+	Provider      string             `bson:"provider" json:"provider"`
+	SocialID      string             `bson:"social_id,omitempty" json:"socialID,omitempty"`
+	SocialEmail   string             `bson:"social_email,omitempty" json:"socialEmail,omitempty"`
+	Email         string             `bson:"email,omitempty" json:"email,omitempty"` // 로컬 계정 연락처. 비밀번호 재설정에 사용
+	EmailVerified bool               `bson:"email_verified" json:"emailVerified"`
+	Identities    []Identity         `bson:"identities,omitempty" json:"identities"`
+	IsProfileSet  bool               `bson:"is_profile_set" json:"isProfileSet"`
+	Role          string             `bson:"role,omitempty" json:"role"`
+	TwoFactor     *TwoFactor         `bson:"two_factor,omitempty" json:"twoFactor,omitempty"`
+	TokenVersion  int                `bson:"token_version,omitempty" json:"-"` // 토큰 전체 폐기 때마다 올림. 토큰에 tv로 들어감
+	CreatedAt     time.Time          `bson:"created_at" json:"createdAt"`
+
+	// 닉네임은 쿨다운이 있어서 마지막 변경 시각을 기록
+	NicknameChangedAt *time.Time `bson:"nickname_changed_at,omitempty" json:"nicknameChangedAt,omitempty"`
+
+	// 탈퇴 신청 후 유예 기간이 끝나면 DeletionScheduledAt에 실제로 삭제됨
+	DeletionScheduledAt *time.Time `bson:"deletion_scheduled_at,omitempty" json:"deletionScheduledAt,omitempty"`
+	DeletionHostPolicy  string     `bson:"deletion_host_policy,omitempty" json:"-"`
 }
 
+// 역할 필드가 생기기 전에 가입한 유저는 일반 유저
+func (u *User) EffectiveRole() string {
+	if u.Role == "" {
+		return RoleUser
+	}
+	return u.Role
+}
+
+// 계정에 연결된 로그인 수단. 로컬 계정은 SocialID 자리에 username이 들어감
+type Identity struct {
+	Key      string    `bson:"key" json:"-"` // provider:socialID, 유니크 인덱스용
+	Provider string    `bson:"provider" json:"provider"`
+	SocialID string    `bson:"social_id" json:"-"`
+	Email    string    `bson:"email,omitempty" json:"email,omitempty"`
+	LinkedAt time.Time `bson:"linked_at" json:"linkedAt"`
+}
+
+// 소셜 로그인 토큰 검증 결과. 이메일은 제공자가 안 줄 수도 있음
+type VerifiedIdentity struct {
+	SocialID string
+	Email    string
+}
+
+func IdentityKey(provider, socialID string) string {
+	return provider + ":" + socialID
+}
+
+const (
+	HostPolicyCancel   = "CANCEL"   // 내가 방장인 모임은 취소
+	HostPolicyTransfer = "TRANSFER" // 가장 먼저 참여한 사람에게 방장 넘김 (기본값)
+)
+
+// 탈퇴한 유저의 채팅에 표시되는 이름
+const DeletedUserName = "탈퇴한 사용자"
+
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
 }
 
 type LoginRequest struct {
@@ -56,3 +128,30 @@ type SetProfileRequest struct {
 	Location   Location `json:"location" binding:"required"`
 	RegionName string   `json:"regionName" binding:"required"`
 }
+
+// 보낸 필드만 수정. 검증 규칙은 SetProfileRequest와 같음
+// 성별은 성비 제한을 우회할 수 있어서 온보딩 이후엔 바꿀 수 없음
+type UpdateProfileRequest struct {
+	Nickname   *string   `json:"nickname"`
+	ProfileURI *string   `json:"profileURI"`
+	Age        *int      `json:"age"`
+	Location   *Location `json:"location"`
+	RegionName *string   `json:"regionName"`
+}
+
+type LinkSocialRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type LinkLocalRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type DeleteAccountRequest struct {
+	HostedMeetings string `json:"hostedMeetings"`
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
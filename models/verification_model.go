@@ -0,0 +1,44 @@
+// models/verification_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	VerificationPurposeEmail         = "EMAIL_VERIFY"
+	VerificationPurposePasswordReset = "PASSWORD_RESET"
+)
+
+// 이메일 인증 코드와 비밀번호 재설정 토큰. 원문은 메일로만 나가고 DB에는 해시만 저장
+type VerificationToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	Purpose   string             `bson:"purpose"`
+	TokenHash string             `bson:"token_hash"`
+	Email     string             `bson:"email"` // 보낸 주소. 그 사이 이메일이 바뀌었으면 인증하지 않음
+	Attempts  int                `bson:"attempts"`
+	UsedAt    *time.Time         `bson:"used_at,omitempty"`
+	ExpiresAt time.Time          `bson:"expires_at"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+type VerifyEmailRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type ChangeEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=8"`
+}
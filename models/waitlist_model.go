@@ -0,0 +1,24 @@
+// models/waitlist_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 정원이 찬 모임의 대기열. 먼저 들어온 순서대로 자리가 나면 자동으로 참여됨
+type WaitlistEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	MeetingID primitive.ObjectID `bson:"meeting_id" json:"meetingID"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"-"`
+	Gender    string             `bson:"gender,omitempty" json:"-"` // 성별 정원이 있으면 자리가 난 성별부터 올림
+	CreatedAt time.Time          `bson:"created_at" json:"createdAt"`
+}
+
+type WaitlistPosition struct {
+	MeetingID primitive.ObjectID `json:"meetingID"`
+	Position  int64              `json:"position"` // 1부터 시작
+	Total     int64              `json:"total"`
+}
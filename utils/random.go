@@ -3,9 +3,12 @@
 package utils
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 )
 
 func GenerateHashUsername(provider string, socialID string) string {
@@ -13,3 +16,28 @@ func GenerateHashUsername(provider string, socialID string) string {
 	h.Write([]byte(provider + socialID))
 	return fmt.Sprintf("u_%s", hex.EncodeToString(h.Sum(nil))[:10])
 }
+
+// 리프레시 토큰 등 클라이언트에 내려주는 불투명 토큰
+func GenerateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// 메일로 보내는 숫자 인증 코드. 앞자리 0도 유지됨
+func GenerateNumericCode(digits int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// DB에는 원문 대신 해시만 저장
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+// utils/totp_test.go
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// RFC 6238 부록 B의 SHA1 시크릿 "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 벡터는 8자리라서 뒤 6자리만 비교
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		t.Run(time.Unix(tt.unix, 0).UTC().Format(time.RFC3339), func(t *testing.T) {
+			got, err := TOTPCode(rfcSecret, TOTPStep(time.Unix(tt.unix, 0)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TOTPCode = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOTPCodeLowercaseSecret(t *testing.T) {
+	got, err := TOTPCode("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", TOTPStep(time.Unix(59, 0)))
+	if err != nil || got != "287082" {
+		t.Errorf("TOTPCode = %q, %v", got, err)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := TOTPStep(now)
+	prev, _ := TOTPCode(rfcSecret, step-1)
+	next, _ := TOTPCode(rfcSecret, step+1)
+	far, _ := TOTPCode(rfcSecret, step+2)
+
+	tests := []struct {
+		name     string
+		code     string
+		skew     int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", "050471", 1, step, true},
+		{"surrounding spaces", " 050471 ", 1, step, true},
+		{"previous step within skew", prev, 1, step - 1, true},
+		{"next step within skew", next, 1, step + 1, true},
+		{"outside skew", far, 1, 0, false},
+		{"no skew rejects previous", prev, 0, 0, false},
+		{"wrong length", "50471", 1, 0, false},
+		{"wrong code", "000000", 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := ValidateTOTP(rfcSecret, tt.code, now, tt.skew)
+			if ok != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("ValidateTOTP(%q) = %d, %v, want %d, %v", tt.code, gotStep, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPBadSecret(t *testing.T) {
+	if _, ok := ValidateTOTP("not base32!", "123456", time.Now(), 1); ok {
+		t.Fatal("invalid secret accepted")
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"abcd-efgh", "abcdefgh"},
+		{" ABCD-EFGH ", "abcdefgh"},
+		{"abcdefgh", "abcdefgh"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeRecoveryCode(tt.in); got != tt.want {
+			t.Errorf("NormalizeRecoveryCode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	code, err := GenerateRecoveryCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) != 9 || code[4] != '-' {
+		t.Errorf("GenerateRecoveryCode = %q", code)
+	}
+}